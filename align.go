@@ -0,0 +1,126 @@
+package lsdp
+
+import "unicode/utf8"
+
+// Match represents an approximate occurrence of a pattern in a text.
+// Start and End are byte offsets of the text, the occurrence is text[Start:End].
+type Match struct {
+	Start int
+	End   int
+	Dist  float64
+}
+
+// SemiGlobal returns the best approximate occurrence of pattern anywhere inside text.
+// Leading and trailing text outside of the occurrence costs nothing,
+// the pattern itself is aligned by cm as in Distance(pattern, occurrence).
+func SemiGlobal(cm CostModel, pattern, text string) Match {
+	pr := []rune(pattern)
+	col := make([]float64, len(pr)+1)
+	start := make([]int, len(pr)+1)
+	for i := 1; i < len(col); i++ {
+		col[i] = col[i-1] + cm.DeleteCost(pr[i-1])
+	}
+
+	best := Match{Start: 0, End: 0, Dist: col[len(pr)]}
+	for end := 0; end < len(text); {
+		tr, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		stepSemiGlobal(cm, pr, tr, end, col, start)
+		if d := col[len(pr)]; d < best.Dist {
+			best = Match{Start: start[len(pr)], End: end, Dist: d}
+		}
+	}
+	return best
+}
+
+// stepSemiGlobal advances the semi-global DP column by the text rune tr ending at offset end
+func stepSemiGlobal(cm CostModel, pr []rune, tr rune, end int, col []float64, start []int) {
+	diagonal, diagonalStart := col[0], start[0]
+	col[0], start[0] = 0, end
+	for i := 1; i < len(col); i++ {
+		rep := diagonal + cm.ReplaceCost(pr[i-1], tr)
+		ins := col[i] + cm.InsertCost(tr)
+		del := col[i-1] + cm.DeleteCost(pr[i-1])
+
+		min, minStart := rep, diagonalStart
+		diagonal, diagonalStart = col[i], start[i]
+		if ins < min {
+			min, minStart = ins, start[i]
+		}
+		if del < min {
+			min, minStart = del, start[i-1]
+		}
+		col[i], start[i] = min, minStart
+	}
+}
+
+// LocalMatch represents the best local alignment between two strings (Smith-Waterman).
+// Spans are byte offsets, the aligned substrings are a[AStart:AEnd] and b[BStart:BEnd].
+type LocalMatch struct {
+	Score  float64
+	AStart int
+	AEnd   int
+	BStart int
+	BEnd   int
+}
+
+// Local returns the best local alignment of a and b.
+// Each matched rune scores reward, each edit scores minus its cost in cm.
+func Local(cm CostModel, reward float64, a, b string) LocalMatch {
+	ar, br := []rune(a), []rune(b)
+	row := make([]float64, len(br)+1)
+	startA := make([]int, len(br)+1)
+	startB := make([]int, len(br)+1)
+	for j := range startB {
+		startB[j] = j
+	}
+
+	var best LocalMatch
+	for i := 1; i < len(ar)+1; i++ {
+		diagonal, diagonalA, diagonalB := row[0], startA[0], startB[0]
+		startA[0] = i
+		for j := 1; j < len(row); j++ {
+			var score float64
+			if ar[i-1] == br[j-1] {
+				score = reward
+			} else {
+				score = -cm.ReplaceCost(ar[i-1], br[j-1])
+			}
+			rep := diagonal + score
+			del := row[j] - cm.DeleteCost(ar[i-1])
+			ins := row[j-1] - cm.InsertCost(br[j-1])
+			up, upA, upB := row[j], startA[j], startB[j]
+
+			max, maxA, maxB := 0.0, i, j
+			if rep > max {
+				max, maxA, maxB = rep, diagonalA, diagonalB
+			}
+			if del > max {
+				max, maxA, maxB = del, upA, upB
+			}
+			if ins > max {
+				max, maxA, maxB = ins, startA[j-1], startB[j-1]
+			}
+			diagonal, diagonalA, diagonalB = up, upA, upB
+			row[j], startA[j], startB[j] = max, maxA, maxB
+
+			if max > best.Score {
+				best = LocalMatch{Score: max, AStart: maxA, AEnd: i, BStart: maxB, BEnd: j}
+			}
+		}
+	}
+
+	offA, offB := runeOffsets(a), runeOffsets(b)
+	best.AStart, best.AEnd = offA[best.AStart], offA[best.AEnd]
+	best.BStart, best.BEnd = offB[best.BStart], offB[best.BEnd]
+	return best
+}
+
+// runeOffsets returns the byte offset of each rune of s followed by len(s)
+func runeOffsets(s string) []int {
+	offs := make([]int, 0, len(s)+1)
+	for i := range s {
+		offs = append(offs, i)
+	}
+	return append(offs, len(s))
+}
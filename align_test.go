@@ -0,0 +1,67 @@
+package lsdp
+
+import "testing"
+
+func TestSemiGlobal(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		Pattern string
+		Text    string
+		Want    Match
+	}{
+		{"", "abc", Match{0, 0, 0}},
+		{"abc", "", Match{0, 0, 3}},
+		{"abc", "xxabcxx", Match{2, 5, 0}},
+		{"abc", "xxabdxx", Match{2, 4, 1}},
+		{"error", "2020-01-01 [eror] disk full", Match{12, 16, 1}},
+		{"こんにちは", "あいこんばんはう", Match{6, 21, 2}},
+		{"こんばんは", "あいこんばんはう", Match{6, 21, 0}},
+		{"cafe", "un café noir", Match{3, 6, 1}},
+	}
+	for i, td := range testdata {
+		if m := SemiGlobal(std, td.Pattern, td.Text); m.Start != td.Want.Start || m.End != td.Want.End || !equals(m.Dist, td.Want.Dist) {
+			t.Errorf(`%d: SemiGlobal("%s", "%s") = %v, want %v`, i, td.Pattern, td.Text, m, td.Want)
+		}
+	}
+
+	// spans are byte offsets which slice the text as is
+	for _, td := range []struct{ Pattern, Text, Want string }{
+		{"こんばんは", "あいこんばんはう", "こんばんは"},
+		{"café", "ün café noir", "café"},
+		{"ばんは", "ab\xffcばxんはd", "xんは"},
+	} {
+		if m := SemiGlobal(std, td.Pattern, td.Text); td.Text[m.Start:m.End] != td.Want {
+			t.Errorf(`SemiGlobal("%s", "%s") = %v, text[Start:End] = %q, want %q`, td.Pattern, td.Text, m, td.Text[m.Start:m.End], td.Want)
+		}
+	}
+
+	wr := ByRune(&std).Replace("b", "B", 0.1)
+	if m := SemiGlobal(wr, "abc", "xaBcx"); m.Start != 1 || m.End != 4 || !equals(m.Dist, 0.1) {
+		t.Errorf(`SemiGlobal(wr, "abc", "xaBcx") = %v, want {1 4 0.1}`, m)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		A    string
+		B    string
+		Want LocalMatch
+	}{
+		{"", "", LocalMatch{0, 0, 0, 0, 0}},
+		{"abc", "xyz", LocalMatch{0, 0, 0, 0, 0}},
+		{"xxabcxx", "yabcy", LocalMatch{6, 2, 5, 1, 4}},
+		{"xxabcdexx", "yabXdey", LocalMatch{7, 2, 7, 1, 6}},
+		{"ああこんにちはあ", "xこんにちy", LocalMatch{8, 6, 18, 1, 13}},
+	}
+	for i, td := range testdata {
+		if m := Local(std, 2, td.A, td.B); m != td.Want {
+			t.Errorf(`%d: Local("%s", "%s") = %v, want %v`, i, td.A, td.B, m, td.Want)
+		}
+	}
+
+	m := Local(std, 2, "ああこんにちはあ", "xこんにちy")
+	if a, b := "ああこんにちはあ"[m.AStart:m.AEnd], "xこんにちy"[m.BStart:m.BEnd]; a != "こんにち" || b != "こんにち" {
+		t.Errorf(`Local() spans slice %q and %q`, a, b)
+	}
+}
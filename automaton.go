@@ -0,0 +1,261 @@
+package lsdp
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// LevenshteinAutomaton is the deterministic automaton accepting strings within maxDist from the query,
+// its states are built lazily from the DP rows clipped at maxDist+1, so it isn't safe for concurrent use.
+type LevenshteinAutomaton struct {
+	query         []rune
+	ins, del, rep int
+	max           int
+	ids           map[string]int
+	rows          [][]int
+	trans         []map[rune]int
+}
+
+// DeadState is the state of LevenshteinAutomaton which never accepts
+const DeadState = -1
+
+// NewAutomaton returns LevenshteinAutomaton of the query under the weights of non-negative integers
+func NewAutomaton(w Weights, query string, maxDist int) (*LevenshteinAutomaton, error) {
+	ins, del, rep, err := integerWeights(w)
+	if err != nil {
+		return nil, err
+	}
+	if maxDist < 0 || maxDist > 254 {
+		return nil, errors.New("lsdp: maxDist of automaton must be in [0, 254]")
+	}
+	la := &LevenshteinAutomaton{
+		query: []rune(query),
+		ins:   ins,
+		del:   del,
+		rep:   rep,
+		max:   maxDist,
+		ids:   make(map[string]int),
+	}
+	row := make([]int, len(la.query)+1)
+	for i := 1; i < len(row); i++ {
+		row[i] = la.clip(row[i-1] + del)
+	}
+	la.state(row)
+	return la, nil
+}
+
+func integerWeights(w Weights) (ins, del, rep int, err error) {
+	for _, c := range []float64{w.Insert, w.Delete, w.Replace} {
+		if c < 0 || c != math.Trunc(c) || c > 255 {
+			return 0, 0, 0, errors.New("lsdp: weights of automaton must be integers in [0, 255]")
+		}
+	}
+	return int(w.Insert), int(w.Delete), int(w.Replace), nil
+}
+
+func (la *LevenshteinAutomaton) clip(c int) int {
+	if c > la.max+1 {
+		return la.max + 1
+	}
+	return c
+}
+
+// state returns the state id of the row, DeadState if no extension of it can be accepted
+func (la *LevenshteinAutomaton) state(row []int) int {
+	min := row[0]
+	for _, c := range row {
+		if c < min {
+			min = c
+		}
+	}
+	if min > la.max {
+		return DeadState
+	}
+	key := make([]byte, len(row))
+	for i, c := range row {
+		key[i] = byte(c)
+	}
+	if id, ok := la.ids[string(key)]; ok {
+		return id
+	}
+	id := len(la.rows)
+	la.ids[string(key)] = id
+	la.rows = append(la.rows, row)
+	la.trans = append(la.trans, make(map[rune]int))
+	return id
+}
+
+// Start returns the initial state
+func (la *LevenshteinAutomaton) Start() int {
+	if len(la.rows) == 0 {
+		return DeadState
+	}
+	return 0
+}
+
+// Step returns the state after reading r
+func (la *LevenshteinAutomaton) Step(state int, r rune) int {
+	if state == DeadState {
+		return DeadState
+	}
+	if next, ok := la.trans[state][r]; ok {
+		return next
+	}
+	row := la.rows[state]
+	next := make([]int, len(row))
+	next[0] = la.clip(row[0] + la.ins)
+	for i := 1; i < len(next); i++ {
+		rep := row[i-1]
+		if la.query[i-1] != r {
+			rep += la.rep
+		}
+		next[i] = la.clip(int(minCost(float64(rep), float64(row[i]+la.ins), float64(next[i-1]+la.del))))
+	}
+	id := la.state(next)
+	la.trans[state][r] = id
+	return id
+}
+
+// Accept returns the distance of the state and whether it's within maxDist
+func (la *LevenshteinAutomaton) Accept(state int) (int, bool) {
+	if state == DeadState {
+		return 0, false
+	}
+	d := la.rows[state][len(la.query)]
+	return d, d <= la.max
+}
+
+// Match returns the distance of s and whether it's within maxDist
+func (la *LevenshteinAutomaton) Match(s string) (int, bool) {
+	state := la.Start()
+	for _, r := range s {
+		if state = la.Step(state, r); state == DeadState {
+			return 0, false
+		}
+	}
+	return la.Accept(state)
+}
+
+// AutomatonIndex is Searcher intersecting LevenshteinAutomaton of the query with the sorted dictionary
+type AutomatonIndex struct {
+	w       Weights
+	maxDist int
+	sorted  []string
+	idxs    []int
+}
+
+// NewAutomatonIndex returns AutomatonIndex of strs under the weights of non-negative integers.
+// Search never returns strings farther than maxDist.
+func NewAutomatonIndex(w Weights, strs []string, maxDist int) (*AutomatonIndex, error) {
+	if _, _, _, err := integerWeights(w); err != nil {
+		return nil, err
+	}
+	if _, err := NewAutomaton(w, "", maxDist); err != nil {
+		return nil, err
+	}
+	ai := &AutomatonIndex{
+		w:       w,
+		maxDist: maxDist,
+		sorted:  append([]string(nil), strs...),
+		idxs:    make([]int, len(strs)),
+	}
+	for i := range ai.idxs {
+		ai.idxs[i] = i
+	}
+	sort.Sort(byStr{ai})
+	return ai, nil
+}
+
+type byStr struct {
+	*AutomatonIndex
+}
+
+func (s byStr) Len() int { return len(s.sorted) }
+
+func (s byStr) Less(i, j int) bool {
+	return s.sorted[i] < s.sorted[j] || s.sorted[i] == s.sorted[j] && s.idxs[i] < s.idxs[j]
+}
+
+func (s byStr) Swap(i, j int) {
+	s.sorted[i], s.sorted[j] = s.sorted[j], s.sorted[i]
+	s.idxs[i], s.idxs[j] = s.idxs[j], s.idxs[i]
+}
+
+// Search returns at most k nearest strings within maxDist from orig, maxDist is capped by the one of the index
+func (ai *AutomatonIndex) Search(orig string, k int, maxDist float64) []Result {
+	max := ai.maxDist
+	if maxDist < float64(max) {
+		if maxDist < 0 {
+			return []Result{}
+		}
+		max = int(math.Floor(maxDist))
+	}
+	la, _ := NewAutomaton(ai.w, orig, max)
+
+	type result struct {
+		idx int
+		Result
+	}
+	var found []result
+	var prev string
+	states := []int{la.Start()} // states[p] is the state after the first p bytes of prev
+	for i := 0; i < len(ai.sorted); {
+		s := ai.sorted[i]
+		p := commonPrefixLen(prev, s)
+		states = states[:p+1]
+		dead := -1
+		for p < len(s) {
+			r, size := utf8.DecodeRuneInString(s[p:])
+			next := la.Step(states[len(states)-1], r)
+			for n := 0; n < size; n++ {
+				states = append(states, next)
+			}
+			if next == DeadState {
+				dead = p + size
+				break
+			}
+			p += size
+		}
+		prev = s
+
+		if dead >= 0 {
+			// skip the strings sharing the dead prefix
+			prefix := s[:dead]
+			i += sort.Search(len(ai.sorted)-i, func(j int) bool {
+				return !strings.HasPrefix(ai.sorted[i+j], prefix)
+			})
+			continue
+		}
+		if d, ok := la.Accept(states[len(states)-1]); ok {
+			found = append(found, result{ai.idxs[i], Result{Str: s, Dist: float64(d)}})
+		}
+		i++
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Dist < found[j].Dist || found[i].Dist == found[j].Dist && found[i].idx < found[j].idx
+	})
+	if k > 0 && k < len(found) {
+		found = found[:k]
+	}
+	rs := make([]Result, len(found))
+	for i, f := range found {
+		rs[i] = f.Result
+	}
+	return rs
+}
+
+// commonPrefixLen returns the byte length of the common prefix at a rune boundary
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	for n > 0 && n < len(b) && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	return n
+}
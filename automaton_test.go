@@ -0,0 +1,83 @@
+package lsdp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLevenshteinAutomaton(t *testing.T) {
+	std := Weights{1, 1, 1}
+	words := []string{"", "a", "book", "back", "books", "cook", "boo", "kitten", "こんにちは", "こんばんは"}
+	for _, w := range []Weights{std, {Insert: 1, Delete: 2, Replace: 1}, {Insert: 2, Delete: 1, Replace: 3}} {
+		for _, q := range []string{"", "book", "こんにちは"} {
+			for max := 0; max <= 3; max++ {
+				la, err := NewAutomaton(w, q, max)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, s := range words {
+					want := w.Distance(q, s)
+					d, ok := la.Match(s)
+					if ok != (want <= float64(max)) || ok && float64(d) != want {
+						t.Errorf(`Automaton(%v, "%s", %d).Match("%s") = %d, %v, want %f`, w, q, max, s, d, ok, want)
+					}
+				}
+			}
+		}
+	}
+
+	for _, w := range []Weights{{1, 1, 0.5}, {-1, 1, 1}, {1, 256, 1}} {
+		if _, err := NewAutomaton(w, "a", 1); err == nil {
+			t.Errorf("NewAutomaton(%v) returns no error", w)
+		}
+	}
+	if _, err := NewAutomaton(std, "a", -1); err == nil {
+		t.Errorf("NewAutomaton() with negative maxDist returns no error")
+	}
+}
+
+func TestAutomatonIndex_Search(t *testing.T) {
+	dict := append(makeDictionary(rand.New(rand.NewSource(1)), 200), "", "getUser", "getUser", "こんにちは", "こんばんは")
+	queries := []string{"", "getUser", "getusr", "setItemz", "isVal", "newListCount", "hasIdd", "xyz", "こんにちわ"}
+	for _, w := range []Weights{{1, 1, 1}, {Insert: 1, Delete: 2, Replace: 1}} {
+		ai, err := NewAutomatonIndex(w, dict, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		linear := Linear(w, dict)
+		for _, q := range queries {
+			for _, td := range []struct {
+				K       int
+				MaxDist float64
+			}{{1, math.Inf(1)}, {5, math.Inf(1)}, {0, 2}, {3, 1.5}, {0, 0}, {0, -1}} {
+				max := math.Min(td.MaxDist, 3)
+				want := linear.Search(q, td.K, max)
+				if rs := ai.Search(q, td.K, td.MaxDist); !equalsResults(rs, want) {
+					t.Errorf(`AutomatonIndex(%v).Search("%s", %d, %f) = %v, want %v`, w, q, td.K, td.MaxDist, rs, want)
+				}
+			}
+		}
+	}
+
+	std := Weights{1, 1, 1}
+	for _, d := range [][]string{dict, nil} {
+		ai, err := NewAutomatonIndex(std, d, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSearcher(t, "AutomatonIndex", ai, std, d, 2)
+	}
+
+	if _, err := NewAutomatonIndex(Weights{1, 1, 0.5}, dict, 1); err == nil {
+		t.Errorf("NewAutomatonIndex() with non-integer weights returns no error")
+	}
+}
+
+func BenchmarkAutomatonIndex_Search(b *testing.B) {
+	ai, _ := NewAutomatonIndex(Weights{1, 1, 1}, makeDictionary(rand.New(rand.NewSource(1)), 1000), 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ai.Search("getUserNam", 1, 2)
+	}
+}
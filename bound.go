@@ -0,0 +1,242 @@
+package lsdp
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+)
+
+// LowerBounder provides a lower bound of the distance between 2 strings, cheaper than measuring it
+type LowerBounder interface {
+	LowerBound(string, string) float64
+}
+
+// BoundedDistancer provides the distance measurement which gives up once the distance exceeds bound
+type BoundedDistancer interface {
+	// DistanceWithin returns the distance and true if it is bound or less,
+	// otherwise a lower bound of the distance greater than bound and false.
+	DistanceWithin(a, b string, bound float64) (float64, bool)
+}
+
+// LowerBound returns the cost of inserting or deleting the difference of rune lengths.
+// It is 0 if any weight is negative.
+func (w Weights) LowerBound(a, b string) float64 {
+	if !w.nonNegative() {
+		return 0
+	}
+	return indelBound(a, b, w.Insert, w.Delete)
+}
+
+// DistanceWithin returns weighted Levenshtein distance if it is bound or less,
+// the DP stops as soon as the minimum of a row exceeds bound.
+func (w Weights) DistanceWithin(a, b string, bound float64) (float64, bool) {
+	if !w.nonNegative() {
+		d := w.Distance(a, b)
+		return d, d <= bound
+	}
+	return distanceWithin(w, w.LowerBound(a, b), a, b, bound)
+}
+
+func (w Weights) nonNegative() bool {
+	return w.Insert >= 0 && w.Delete >= 0 && w.Replace >= 0
+}
+
+// LowerBound returns the difference of rune lengths multiplied by the cheapest insert or delete cost of any rule.
+// It is 0 if any cost is negative.
+func (wr *WeightsByRune) LowerBound(a, b string) float64 {
+	if !wr.nonNegative() {
+		return 0
+	}
+	ins, del := wr.w.Insert, wr.w.Delete
+	for _, c := range wr.insRune {
+		ins = math.Min(ins, c)
+	}
+	for _, c := range wr.insClass {
+		ins = math.Min(ins, c.cost)
+	}
+	for _, c := range wr.delRune {
+		del = math.Min(del, c)
+	}
+	for _, c := range wr.delClass {
+		del = math.Min(del, c.cost)
+	}
+	return indelBound(a, b, ins, del)
+}
+
+// DistanceWithin returns weighted levenshtein distance by rune if it is bound or less,
+// the DP stops as soon as the minimum of a row exceeds bound.
+func (wr *WeightsByRune) DistanceWithin(a, b string, bound float64) (float64, bool) {
+	if !wr.nonNegative() {
+		d := wr.Distance(a, b)
+		return d, d <= bound
+	}
+	return distanceWithin(wr, wr.LowerBound(a, b), a, b, bound)
+}
+
+func (wr *WeightsByRune) nonNegative() bool {
+	if !wr.w.nonNegative() {
+		return false
+	}
+	for _, c := range wr.insRune {
+		if c < 0 {
+			return false
+		}
+	}
+	for _, c := range wr.delRune {
+		if c < 0 {
+			return false
+		}
+	}
+	for _, c := range wr.repRune {
+		if c < 0 {
+			return false
+		}
+	}
+	for _, c := range wr.insClass {
+		if c.cost < 0 {
+			return false
+		}
+	}
+	for _, c := range wr.delClass {
+		if c.cost < 0 {
+			return false
+		}
+	}
+	for _, c := range wr.repClass {
+		if c.cost < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// LowerBound returns the difference of rune lengths multiplied by the cheapest insert or delete cost of any rule
+func (f *FrozenWeightsByRune) LowerBound(a, b string) float64 {
+	return f.wr.LowerBound(a, b)
+}
+
+// DistanceWithin returns weighted levenshtein distance by rune if it is bound or less
+func (f *FrozenWeightsByRune) DistanceWithin(a, b string, bound float64) (float64, bool) {
+	return f.wr.DistanceWithin(a, b, bound)
+}
+
+// indelBound returns the cost of inserting or deleting the difference of rune lengths from a to b
+func indelBound(a, b string, ins, del float64) float64 {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if la < lb {
+		return float64(lb-la) * ins
+	}
+	return float64(la-lb) * del
+}
+
+// distanceWithin computes the distance row by row after checking the lower bound lb, costs of cm must not be negative
+func distanceWithin(cm CostModel, lb float64, a, b string, bound float64) (float64, bool) {
+	if lb > bound {
+		return lb, false
+	}
+	ar, br := []rune(a), []rune(b)
+	row := make([]float64, len(ar)+1)
+	if w, ok := cm.(Weights); ok {
+		return w.rowDistanceWithin(row, ar, br, bound)
+	}
+	return rowDistanceWithin(cm, row, ar, br, bound)
+}
+
+// pruner measures distances concurrently sharing the k-th best distance found so far,
+// candidates whose lower bound exceeds it can't be among the k nearest and are skipped.
+type pruner struct {
+	dm   DistanceMeasurer
+	lb   LowerBounder
+	bd   BoundedDistancer
+	k    int
+	mu   sync.Mutex
+	best distHeap
+}
+
+func newPruner(dm DistanceMeasurer, k int) *pruner {
+	p := &pruner{dm: dm, k: k}
+	p.lb, _ = dm.(LowerBounder)
+	p.bd, _ = dm.(BoundedDistancer)
+	return p
+}
+
+// bound returns the k-th best distance so far, +Inf until k distances are found
+func (p *pruner) bound() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.best) < p.k {
+		return math.Inf(1)
+	}
+	return p.best[0]
+}
+
+// scan measures the distances from orig to strs by a pool of workers, oks[i] is false if strs[i] is skipped.
+// Candidates are visited in ascending order of their lower bounds, so the bound tightens early.
+func (p *pruner) scan(orig string, strs []string) (dists []float64, oks []bool) {
+	order := make([]int, len(strs))
+	for i := range order {
+		order[i] = i
+	}
+	lbs := make([]float64, len(strs))
+	for i, s := range strs {
+		if p.lb != nil {
+			lbs[i] = p.lb.LowerBound(orig, s)
+		} else {
+			lbs[i] = math.Inf(-1)
+		}
+	}
+	if p.lb != nil {
+		sort.SliceStable(order, func(i, j int) bool {
+			return lbs[order[i]] < lbs[order[j]]
+		})
+	}
+
+	dists, oks = make([]float64, len(strs)), make([]bool, len(strs))
+	parallelRows(len(order), func(n int) {
+		i := order[n]
+		dists[i], oks[i] = p.distance(orig, strs[i], lbs[i])
+	})
+	return dists, oks
+}
+
+// distance returns the distance between a and b whose lower bound is lb, false if it exceeds the k-th best distance
+func (p *pruner) distance(a, b string, lb float64) (float64, bool) {
+	bound := p.bound()
+	if lb > bound {
+		return 0, false
+	}
+	var d float64
+	if p.bd != nil && !math.IsInf(bound, 1) {
+		var ok bool
+		if d, ok = p.bd.DistanceWithin(a, b, bound); !ok {
+			return 0, false
+		}
+	} else {
+		d = p.dm.Distance(a, b)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.best) < p.k {
+		heap.Push(&p.best, d)
+	} else if d < p.best[0] {
+		p.best[0] = d
+		heap.Fix(&p.best, 0)
+	}
+	return d, true
+}
+
+// distHeap is max-heap of distances
+type distHeap []float64
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i] > h[j] }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(float64)) }
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,117 @@
+package lsdp
+
+import (
+	"math/rand"
+	"testing"
+	"unicode"
+)
+
+func boundCostModels() []CostModel {
+	std := Weights{1, 1, 1}
+	return []CostModel{
+		std,
+		Weights{Insert: 0.5, Delete: 2, Replace: 1.5},
+		ByRune(&std).Insert("a", 0.1).Delete("b", 0.3).Replace("c", "d", 0.2),
+		ByRune(&Weights{2, 3, 1}).InsertClass(unicode.IsUpper, 0.5).DeleteClass(unicode.IsDigit, 0.25),
+		ByRune(&std).Insert("a", 0.1).Freeze(),
+	}
+}
+
+func TestLowerBound(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, cm := range boundCostModels() {
+		lb := cm.(LowerBounder)
+		for n := 0; n < 200; n++ {
+			a, b := randomString(rnd, rnd.Intn(8)), randomString(rnd, rnd.Intn(8))
+			if l, d := lb.LowerBound(a, b), cm.Distance(a, b); l > d+1e-9 {
+				t.Errorf(`%v: LowerBound("%s", "%s") = %f, greater than Distance() = %f`, cm, a, b, l, d)
+			}
+		}
+	}
+
+	testdata := []struct {
+		Cm   LowerBounder
+		A, B string
+		Want float64
+	}{
+		{Weights{1, 1, 1}, "abc", "a", 2},
+		{Weights{Insert: 0.5, Delete: 2, Replace: 1}, "a", "abcd", 1.5},
+		{Weights{Insert: 0.5, Delete: 2, Replace: 1}, "abcd", "a", 6},
+		{Weights{Insert: 1, Delete: 1, Replace: -1}, "abc", "a", 0},
+		{ByRune(&Weights{1, 1, 1}).Insert("x", 0.25), "", "yy", 0.5},
+		{ByRune(&Weights{1, 1, 1}).DeleteClass(unicode.IsDigit, 0.1), "123", "", 0.3},
+		{ByRune(&Weights{1, 1, 1}).Replace("a", "b", -1), "abc", "", 0},
+	}
+	for i, td := range testdata {
+		if l := td.Cm.LowerBound(td.A, td.B); !equals(l, td.Want) {
+			t.Errorf(`%d: LowerBound("%s", "%s") = %f, want %f`, i, td.A, td.B, l, td.Want)
+		}
+	}
+}
+
+func TestDistanceWithin(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	cms := append(boundCostModels(), Weights{Insert: 1, Delete: 1, Replace: -0.5})
+	for _, cm := range cms {
+		bd := cm.(BoundedDistancer)
+		for n := 0; n < 300; n++ {
+			a, b := randomString(rnd, rnd.Intn(10)), randomString(rnd, rnd.Intn(10))
+			bound := rnd.Float64() * 6
+			d := cm.Distance(a, b)
+			got, ok := bd.DistanceWithin(a, b, bound)
+			if ok != (d <= bound) {
+				t.Errorf(`%v: DistanceWithin("%s", "%s", %f) = %t, Distance() = %f`, cm, a, b, bound, ok, d)
+			} else if ok && got != d {
+				t.Errorf(`%v: DistanceWithin("%s", "%s", %f) = %f, want %f`, cm, a, b, bound, got, d)
+			} else if !ok && (got <= bound || got > d+1e-9) {
+				t.Errorf(`%v: DistanceWithin("%s", "%s", %f) = %f, not in (bound, %f]`, cm, a, b, bound, got, d)
+			}
+		}
+	}
+}
+
+func TestNearest_Pruned(t *testing.T) {
+	dict := makeDictionary(rand.New(rand.NewSource(1)), 300)
+	queries := []string{"", "getUser", "getusr", "setItemz", "isVal", "newListCount", "xyz"}
+	for _, cm := range boundCostModels() {
+		plain := DistanceFunc(cm.Distance)
+		for _, q := range queries {
+			s, d := Nearest(cm, q, dict)
+			ws, wd := Nearest(plain, q, dict)
+			if s != ws || d != wd {
+				t.Errorf(`%v: Nearest("%s") = %s(%f), want %s(%f)`, cm, q, s, d, ws, wd)
+			}
+			for _, k := range []int{1, 3, 10, 500} {
+				if rs, want := NearestK(cm, q, dict, k), NearestK(plain, q, dict, k); !equalsResults(rs, want) {
+					t.Errorf(`%v: NearestK("%s", %d) = %v, want %v`, cm, q, k, rs, want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNearest_Pruned(b *testing.B) {
+	dict := makeDictionary(rand.New(rand.NewSource(1)), 1000)
+	std := Weights{1, 1, 1}
+	b.Run("pruned", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Nearest(std, "getUserNam", dict)
+		}
+	})
+	b.Run("plain", func(b *testing.B) {
+		plain := DistanceFunc(std.Distance)
+		for i := 0; i < b.N; i++ {
+			Nearest(plain, "getUserNam", dict)
+		}
+	})
+}
+
+func BenchmarkDistanceWithin(b *testing.B) {
+	std := Weights{1, 1, 1}
+	rnd := rand.New(rand.NewSource(1))
+	a, c := randomString(rnd, 64), randomString(rnd, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		std.DistanceWithin(a, c, 3)
+	}
+}
@@ -0,0 +1,125 @@
+package lsdp
+
+import (
+	"container/list"
+	"encoding/gob"
+	"io"
+	"sync"
+)
+
+// CacheOptions represents options of Cached
+type CacheOptions struct {
+	// Capacity is the maximum number of cached pairs, unlimited if 0 or less
+	Capacity int
+	// Symmetric shares the entry of Distance(a, b) with Distance(b, a)
+	Symmetric bool
+}
+
+// CacheStats represents statistics of CachedMeasurer
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Len    int
+}
+
+// CachedMeasurer is DistanceMeasurer memoizing distances with LRU eviction, it's safe for concurrent use
+type CachedMeasurer struct {
+	dm    DistanceMeasurer
+	opts  CacheOptions
+	mu    sync.Mutex
+	lru   *list.List
+	items map[[2]string]*list.Element
+	stats CacheStats
+}
+
+type cacheEntry struct {
+	Key  [2]string
+	Dist float64
+}
+
+// Cached returns what wrapped the DistanceMeasurer with memoization
+func Cached(dm DistanceMeasurer, opts CacheOptions) *CachedMeasurer {
+	return &CachedMeasurer{
+		dm:    dm,
+		opts:  opts,
+		lru:   list.New(),
+		items: make(map[[2]string]*list.Element),
+	}
+}
+
+// Distance returns the cached distance, or calls the wrapped DistanceMeasurer and caches it
+func (c *CachedMeasurer) Distance(a, b string) float64 {
+	key := c.key(a, b)
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		c.lru.MoveToFront(e)
+		c.stats.Hits++
+		d := e.Value.(*cacheEntry).Dist
+		c.mu.Unlock()
+		return d
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	d := c.dm.Distance(a, b)
+
+	c.mu.Lock()
+	c.put(key, d)
+	c.mu.Unlock()
+	return d
+}
+
+// Stats returns the statistics of the cache
+func (c *CachedMeasurer) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Len = c.lru.Len()
+	return s
+}
+
+// Save writes the cached distances to w, Load restores them
+func (c *CachedMeasurer) Save(w io.Writer) error {
+	c.mu.Lock()
+	entries := make([]cacheEntry, 0, c.lru.Len())
+	for e := c.lru.Back(); e != nil; e = e.Prev() {
+		entries = append(entries, *e.Value.(*cacheEntry))
+	}
+	c.mu.Unlock()
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// Load reads the distances written by Save into the cache
+func (c *CachedMeasurer) Load(r io.Reader) error {
+	var entries []cacheEntry
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range entries {
+		c.put(c.key(e.Key[0], e.Key[1]), e.Dist)
+	}
+	return nil
+}
+
+func (c *CachedMeasurer) key(a, b string) [2]string {
+	if c.opts.Symmetric && b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+func (c *CachedMeasurer) put(key [2]string, d float64) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*cacheEntry).Dist = d
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.lru.PushFront(&cacheEntry{Key: key, Dist: d})
+	if c.opts.Capacity > 0 && c.lru.Len() > c.opts.Capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).Key)
+	}
+}
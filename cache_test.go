@@ -0,0 +1,79 @@
+package lsdp
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	var calls int
+	var mu sync.Mutex
+	var counted DistanceFunc = func(a, b string) float64 {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return Weights{1, 1, 1}.Distance(a, b)
+	}
+
+	c := Cached(counted, CacheOptions{Capacity: 2})
+	testdata := []struct {
+		A     string
+		B     string
+		Dist  float64
+		Calls int
+	}{
+		{"book", "back", 2, 1},
+		{"book", "back", 2, 1},
+		{"back", "book", 2, 2},
+		{"cook", "book", 1, 3},
+		{"book", "back", 2, 4}, // evicted
+	}
+	for i, td := range testdata {
+		if d := c.Distance(td.A, td.B); d != td.Dist || calls != td.Calls {
+			t.Errorf(`%d: Distance("%s", "%s") = %f with %d calls, want %f with %d calls`, i, td.A, td.B, d, calls, td.Dist, td.Calls)
+		}
+	}
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 4 || s.Len != 2 {
+		t.Errorf("Stats() = %+v, want {Hits:1 Misses:4 Len:2}", s)
+	}
+
+	calls = 0
+	sc := Cached(counted, CacheOptions{Symmetric: true})
+	sc.Distance("book", "back")
+	sc.Distance("back", "book")
+	if calls != 1 {
+		t.Errorf("symmetric cache calls %d times, want 1", calls)
+	}
+}
+
+func TestCached_SaveLoad(t *testing.T) {
+	c := Cached(Weights{1, 1, 1}, CacheOptions{})
+	group := []string{"apple", "orange", "lemon", "water melon"}
+	DistanceAll(c, "mon", group)
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var never DistanceFunc = func(_, _ string) float64 {
+		t.Errorf("restored cache calls the wrapped DistanceMeasurer")
+		return 0
+	}
+	restored := Cached(never, CacheOptions{})
+	if err := restored.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ds := DistanceAll(restored, "mon", group)
+	want := []float64{5, 5, 2, 8}
+	for i := range ds {
+		if ds[i] != want[i] {
+			t.Errorf("restored DistanceAll() = %v, want %v", ds, want)
+			break
+		}
+	}
+
+	if err := restored.Load(bytes.NewBufferString("broken")); err == nil {
+		t.Errorf("Load() of broken data returns no error")
+	}
+}
@@ -0,0 +1,111 @@
+package lsdp
+
+import "math"
+
+// Calculator computes weighted Levenshtein distances of the CostModel reusing its buffers,
+// it doesn't allocate once the buffers have grown enough.
+// Calculator isn't safe for concurrent use, pool them with sync.Pool instead.
+type Calculator struct {
+	cm        CostModel
+	w         Weights
+	isWeights bool
+	row       []float64
+	ar, br    []rune
+}
+
+// NewCalculator returns Calculator of the CostModel
+func NewCalculator(cm CostModel) *Calculator {
+	c := &Calculator{cm: cm}
+	c.w, c.isWeights = cm.(Weights)
+	return c
+}
+
+// Distance returns weighted Levenshtein distance of the CostModel
+func (c *Calculator) Distance(a, b string) float64 {
+	c.ar, c.br = appendRunes(c.ar[:0], a), appendRunes(c.br[:0], b)
+	if cap(c.row) < len(c.ar)+1 {
+		c.row = make([]float64, len(c.ar)+1)
+	}
+	row := c.row[:len(c.ar)+1]
+	if c.isWeights {
+		return c.w.rowDistance(row, c.ar, c.br)
+	}
+	return rowDistance(c.cm, row, c.ar, c.br)
+}
+
+func appendRunes(rs []rune, s string) []rune {
+	for _, r := range s {
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// rowDistance computes the distance by the fixed weights without calling CostModel methods, row has length len(ar)+1
+func (w Weights) rowDistance(row []float64, ar, br []rune) float64 {
+	d, _ := w.rowDistanceWithin(row, ar, br, math.Inf(1))
+	return d
+}
+
+// rowDistanceWithin is the fixed weights version of rowDistanceWithin
+func (w Weights) rowDistanceWithin(row []float64, ar, br []rune, bound float64) (float64, bool) {
+	row[0] = 0
+	for i := 1; i < len(row); i++ {
+		row[i] = row[i-1] + w.Delete
+	}
+	for j := 1; j < len(br)+1; j++ {
+		diagonal := row[0]
+		row[0] += w.Insert
+		min := row[0]
+		for i := 1; i < len(row); i++ {
+			rep := diagonal
+			if ar[i-1] != br[j-1] {
+				rep += w.Replace
+			}
+			diagonal = row[i]
+			row[i] = minCost(rep, row[i]+w.Insert, row[i-1]+w.Delete)
+			if row[i] < min {
+				min = row[i]
+			}
+		}
+		if min > bound {
+			return min, false
+		}
+	}
+	d := row[len(row)-1]
+	return d, d <= bound
+}
+
+// rowDistance computes the distance by the costs of cm in the row buffer of length len(ar)+1
+func rowDistance(cm CostModel, row []float64, ar, br []rune) float64 {
+	d, _ := rowDistanceWithin(cm, row, ar, br, math.Inf(1))
+	return d
+}
+
+// rowDistanceWithin computes the distance in the row buffer of length len(ar)+1 if it is bound or less,
+// otherwise returns the minimum of the first row exceeding bound and false.
+// Costs of cm must not be negative for a finite bound, then the minimum of a row never decreases.
+func rowDistanceWithin(cm CostModel, row []float64, ar, br []rune, bound float64) (float64, bool) {
+	row[0] = 0
+	for i := 1; i < len(row); i++ {
+		row[i] = row[i-1] + cm.DeleteCost(ar[i-1])
+	}
+	for j := 1; j < len(br)+1; j++ {
+		diagonal := row[0]
+		ins := cm.InsertCost(br[j-1])
+		row[0] += ins
+		min := row[0]
+		for i := 1; i < len(row); i++ {
+			rep := diagonal + cm.ReplaceCost(ar[i-1], br[j-1])
+			diagonal = row[i]
+			row[i] = minCost(rep, row[i]+ins, row[i-1]+cm.DeleteCost(ar[i-1]))
+			if row[i] < min {
+				min = row[i]
+			}
+		}
+		if min > bound {
+			return min, false
+		}
+	}
+	d := row[len(row)-1]
+	return d, d <= bound
+}
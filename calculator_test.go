@@ -0,0 +1,76 @@
+package lsdp
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCalculator(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).Insert("a", 0.1).Delete("b", 0.2).Replace("c", "d", 0.3)
+	testdata := []struct {
+		A string
+		B string
+	}{
+		{"", ""},
+		{"abc", ""},
+		{"", "abc"},
+		{"kitten", "sitting"},
+		{"こんにちは", "こんばんは"},
+		{"abcabcabc", "dadada"},
+		{"a", "abcabcabcabcabc"},
+	}
+	for _, cm := range []CostModel{std, Weights{Insert: 0.1, Delete: 1, Replace: 0.01}, wr} {
+		c := NewCalculator(cm)
+		for i, td := range testdata {
+			if d, want := c.Distance(td.A, td.B), cm.Distance(td.A, td.B); !equals(d, want) {
+				t.Errorf(`%d: Calculator(%v).Distance("%s", "%s") = %f, want %f`, i, cm, td.A, td.B, d, want)
+			}
+		}
+	}
+}
+
+func TestCalculator_Allocs(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).Insert("a", 0.1).Delete("b", 0.2).Replace("c", "d", 0.3)
+	for _, cm := range []CostModel{std, wr} {
+		c := NewCalculator(cm)
+		if n := testing.AllocsPerRun(100, func() {
+			c.Distance("kitten", "sitting")
+			c.Distance("こんにちは", "こんばんは")
+		}); n != 0 {
+			t.Errorf("Calculator(%T).Distance() allocates %f times", cm, n)
+		}
+	}
+}
+
+func TestCalculator_Pool(t *testing.T) {
+	std := Weights{1, 1, 1}
+	pool := sync.Pool{New: func() interface{} { return NewCalculator(std) }}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c := pool.Get().(*Calculator)
+				if d := c.Distance("kitten", "sitting"); d != 3 {
+					t.Errorf("Distance() = %f, want 3", d)
+				}
+				pool.Put(c)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCalculator(b *testing.B) {
+	c := NewCalculator(Weights{1, 1, 1})
+	s := "abababababababababababababababababababababab"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Distance(s, benchLongInput)
+		c.Distance(benchLongInput, s)
+	}
+}
@@ -0,0 +1,145 @@
+package lsdp
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MetricProperty represents an axiom of metric
+type MetricProperty int
+
+// Metric axioms
+const (
+	NonNegativity      MetricProperty = iota // d(a, b) >= 0
+	Identity                                 // d(a, b) == 0 if and only if a == b
+	Symmetry                                 // d(a, b) == d(b, a)
+	TriangleInequality                       // d(a, c) <= d(a, b) + d(b, c)
+)
+
+var metricPropertyNames = [...]string{"non-negativity", "identity", "symmetry", "triangle inequality"}
+
+func (p MetricProperty) String() string {
+	if p < 0 || int(p) >= len(metricPropertyNames) {
+		return fmt.Sprintf("MetricProperty(%d)", int(p))
+	}
+	return metricPropertyNames[p]
+}
+
+// Violation represents a counterexample of MetricProperty.
+// Dists holds d(A, B), d(B, A) for Symmetry, d(A, C), d(A, B), d(B, C) for TriangleInequality and d(A, B) for others.
+type Violation struct {
+	Property MetricProperty
+	A, B, C  string
+	Dists    []float64
+}
+
+func (v Violation) String() string {
+	switch v.Property {
+	case Symmetry:
+		return fmt.Sprintf(`%s: d("%s", "%s") = %g but d("%s", "%s") = %g`, v.Property, v.A, v.B, v.Dists[0], v.B, v.A, v.Dists[1])
+	case TriangleInequality:
+		return fmt.Sprintf(`%s: d("%s", "%s") = %g > d("%s", "%s") + d("%s", "%s") = %g + %g`,
+			v.Property, v.A, v.C, v.Dists[0], v.A, v.B, v.B, v.C, v.Dists[1], v.Dists[2])
+	}
+	return fmt.Sprintf(`%s: d("%s", "%s") = %g`, v.Property, v.A, v.B, v.Dists[0])
+}
+
+// CheckOptions represents options of CheckMetric
+type CheckOptions struct {
+	// Samples is the number of random pairs and triples to check, all of them are checked if 0 or less
+	Samples int
+	// Rand is the source of sampling, a fixed seed is used if nil
+	Rand *rand.Rand
+	// Epsilon is the tolerance of comparison, 1e-9 if 0
+	Epsilon float64
+	// MaxViolations stops checking when reached, unlimited if 0 or less
+	MaxViolations int
+}
+
+// CheckMetric checks whether dm satisfies the metric axioms over strings in corpus, and returns the counterexamples
+func CheckMetric(dm DistanceMeasurer, corpus []string, opts CheckOptions) []Violation {
+	c := &checker{dm: dm, eps: opts.Epsilon, max: opts.MaxViolations}
+	if c.eps == 0 {
+		c.eps = 1e-9
+	}
+	if len(corpus) == 0 {
+		return nil
+	}
+
+	if opts.Samples <= 0 {
+		m := CrossDistance(dm, corpus, corpus)
+		c.d = func(i, j int) float64 { return m[i][j] }
+		for i := range corpus {
+			for j := range corpus {
+				if i <= j {
+					c.checkPair(corpus, i, j)
+				}
+				for k := range corpus {
+					c.checkTriple(corpus, i, j, k)
+				}
+			}
+		}
+		return c.violations
+	}
+
+	rnd := opts.Rand
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(1))
+	}
+	c.d = func(i, j int) float64 { return dm.Distance(corpus[i], corpus[j]) }
+	for n := 0; n < opts.Samples; n++ {
+		i, j, k := rnd.Intn(len(corpus)), rnd.Intn(len(corpus)), rnd.Intn(len(corpus))
+		c.checkPair(corpus, i, j)
+		c.checkTriple(corpus, i, j, k)
+	}
+	return c.violations
+}
+
+type checker struct {
+	dm         DistanceMeasurer
+	d          func(i, j int) float64
+	eps        float64
+	max        int
+	violations []Violation
+}
+
+func (c *checker) full() bool {
+	return c.max > 0 && len(c.violations) >= c.max
+}
+
+func (c *checker) report(v Violation) {
+	if !c.full() {
+		c.violations = append(c.violations, v)
+	}
+}
+
+// checkPair checks the axioms of the pair in both directions
+func (c *checker) checkPair(corpus []string, i, j int) {
+	c.checkDirection(corpus[i], corpus[j], c.d(i, j))
+	if i == j {
+		return
+	}
+	c.checkDirection(corpus[j], corpus[i], c.d(j, i))
+	if dab, dba := c.d(i, j), c.d(j, i); dab-dba > c.eps || dba-dab > c.eps {
+		c.report(Violation{Property: Symmetry, A: corpus[i], B: corpus[j], Dists: []float64{dab, dba}})
+	}
+}
+
+func (c *checker) checkDirection(a, b string, d float64) {
+	if d < -c.eps {
+		c.report(Violation{Property: NonNegativity, A: a, B: b, Dists: []float64{d}})
+	}
+	if zero := d <= c.eps && d >= -c.eps; zero != (a == b) {
+		c.report(Violation{Property: Identity, A: a, B: b, Dists: []float64{d}})
+	}
+}
+
+func (c *checker) checkTriple(corpus []string, i, j, k int) {
+	if c.full() {
+		return
+	}
+	dac, dab, dbc := c.d(i, k), c.d(i, j), c.d(j, k)
+	if dac > dab+dbc+c.eps {
+		c.report(Violation{Property: TriangleInequality, A: corpus[i], B: corpus[j], C: corpus[k], Dists: []float64{dac, dab, dbc}})
+	}
+}
@@ -0,0 +1,71 @@
+package lsdp
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCheckMetric(t *testing.T) {
+	std := Weights{1, 1, 1}
+	corpus := []string{"", "a", "ab", "ba", "abc", "book", "back", "こんにちは"}
+	var negative DistanceFunc = func(a, b string) float64 {
+		return -std.Distance(a, b)
+	}
+	var constd DistanceFunc = func(_, _ string) float64 {
+		return 1
+	}
+	testdata := []struct {
+		DM    DistanceMeasurer
+		Props []MetricProperty
+	}{
+		{std, nil},
+		{Jaro{}, []MetricProperty{TriangleInequality}},
+		{Weights{Insert: 1, Delete: 2, Replace: 1}, []MetricProperty{Symmetry}},
+		{Weights{Insert: 1, Delete: 1, Replace: 3}, nil},
+		{Weights{Insert: 1, Delete: 1, Replace: 0}, []MetricProperty{Identity}},
+		{negative, []MetricProperty{NonNegativity, TriangleInequality}},
+		{constd, []MetricProperty{Identity}},
+		{ByRune(&std).Insert("a", 0.1), []MetricProperty{Symmetry}},
+	}
+	for i, td := range testdata {
+		vs := CheckMetric(td.DM, corpus, CheckOptions{})
+		found := make(map[MetricProperty]bool)
+		for _, v := range vs {
+			found[v.Property] = true
+		}
+		if len(found) != len(td.Props) {
+			t.Errorf("%d: CheckMetric() found %v, want %v\n%v", i, found, td.Props, vs)
+			continue
+		}
+		for _, p := range td.Props {
+			if !found[p] {
+				t.Errorf("%d: CheckMetric() didn't find %s", i, p)
+			}
+		}
+	}
+}
+
+func TestCheckMetric_Sampling(t *testing.T) {
+	corpus := []string{"", "a", "ab", "ba", "abc", "book", "back"}
+	asym := Weights{Insert: 1, Delete: 2, Replace: 1}
+	vs := CheckMetric(asym, corpus, CheckOptions{Samples: 100, Rand: rand.New(rand.NewSource(2)), MaxViolations: 3})
+	if len(vs) != 3 {
+		t.Fatalf("CheckMetric() found %d violations, want 3\n%v", len(vs), vs)
+	}
+	for _, v := range vs {
+		if v.Property != Symmetry {
+			t.Errorf("CheckMetric() found %v, want only symmetry", v)
+		}
+		if !strings.HasPrefix(v.String(), "symmetry: ") {
+			t.Errorf("Violation.String() = %s", v)
+		}
+	}
+
+	if vs := CheckMetric(Weights{1, 1, 1}, corpus, CheckOptions{Samples: 100}); len(vs) != 0 {
+		t.Errorf("CheckMetric() found %v, want none", vs)
+	}
+	if vs := CheckMetric(Weights{1, 1, 1}, nil, CheckOptions{Samples: 100}); len(vs) != 0 {
+		t.Errorf("CheckMetric(nil) found %v, want none", vs)
+	}
+}
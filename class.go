@@ -0,0 +1,47 @@
+package lsdp
+
+import (
+	"regexp"
+	"unicode"
+)
+
+// RuneClass represents a set of runes by predicate, e.g. unicode.IsSpace
+type RuneClass func(rune) bool
+
+// InTable returns RuneClass of the runes in any of the Unicode range tables
+func InTable(tables ...*unicode.RangeTable) RuneClass {
+	return func(r rune) bool {
+		return unicode.IsOneOf(tables, r)
+	}
+}
+
+// RegexpClass returns RuneClass of the runes matched by a regexp character class, e.g. `[[:punct:]]` or `\p{Han}`
+func RegexpClass(charClass string) (RuneClass, error) {
+	re, err := regexp.Compile(`^(?:` + charClass + `)$`)
+	if err != nil {
+		return nil, err
+	}
+	return func(r rune) bool {
+		return re.MatchString(string(r))
+	}, nil
+}
+
+// MustRegexpClass is like RegexpClass but panics if the character class cannot be parsed
+func MustRegexpClass(charClass string) RuneClass {
+	c, err := RegexpClass(charClass)
+	if err != nil {
+		panic(`lsdp: RegexpClass(` + charClass + `): ` + err.Error())
+	}
+	return c
+}
+
+type classCost struct {
+	class RuneClass
+	cost  float64
+}
+
+type classPairCost struct {
+	src  RuneClass
+	dest RuneClass
+	cost float64
+}
@@ -0,0 +1,66 @@
+package lsdp
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestRuneClass(t *testing.T) {
+	han := InTable(unicode.Han)
+	punct := MustRegexpClass(`[[:punct:]]`)
+	testdata := []struct {
+		Class RuneClass
+		R     rune
+		Want  bool
+	}{
+		{han, '漢', true},
+		{han, 'か', false},
+		{han, 'a', false},
+		{punct, '!', true},
+		{punct, 'a', false},
+		{RuneClass(unicode.IsSpace), '\t', true},
+	}
+	for i, td := range testdata {
+		if b := td.Class(td.R); b != td.Want {
+			t.Errorf(`%d: class('%c') = %v, want %v`, i, td.R, b, td.Want)
+		}
+	}
+
+	if _, err := RegexpClass(`[a-`); err == nil {
+		t.Errorf("RegexpClass(`[a-`) returns no error")
+	}
+}
+
+func TestWeightsByRune_Class(t *testing.T) {
+	std := Weights{1, 1, 1}
+	digit := RuneClass(unicode.IsDigit)
+	space := RuneClass(unicode.IsSpace)
+	wrIns := ByRune(&std).InsertClass(digit, 0.1)
+	wrDel := ByRune(&std).DeleteClass(space, 0.01)
+	wrRep := ByRune(&std).ReplaceClass(digit, digit, 0.001)
+	wrPrec := ByRune(&std).
+		Insert("0", 0.5).
+		InsertClass(digit, 0.1).
+		InsertClass(InTable(unicode.Nd, unicode.Lu), 0.2)
+	testdata := []struct {
+		WR   *WeightsByRune
+		A    string
+		B    string
+		Dist float64
+	}{
+		{wrIns, "a", "a12", 0.2},
+		{wrIns, "a", "ab", 1},
+		{wrDel, "a b\t", "ab", 0.02},
+		{wrRep, "a12", "a21", 0.002},
+		{wrRep, "a11", "a11", 0},
+		{wrRep, "a1", "ab", 1},
+		{wrPrec, "", "0", 0.5},
+		{wrPrec, "", "1", 0.2},
+		{wrPrec, "", "A", 0.2},
+	}
+	for i, td := range testdata {
+		if d := td.WR.Distance(td.A, td.B); !equals(d, td.Dist) {
+			t.Errorf(`%d: wr.Distance("%s", "%s") is %f, want %f`, i, td.A, td.B, d, td.Dist)
+		}
+	}
+}
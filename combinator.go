@@ -0,0 +1,74 @@
+package lsdp
+
+// Max returns DistanceMeasurer of the maximum distance among dms
+func Max(dms ...DistanceMeasurer) DistanceMeasurer {
+	return DistanceFunc(func(a, b string) float64 {
+		var max float64
+		for i, dm := range dms {
+			if d := dm.Distance(a, b); i == 0 || d > max {
+				max = d
+			}
+		}
+		return max
+	})
+}
+
+// Min returns DistanceMeasurer of the minimum distance among dms
+func Min(dms ...DistanceMeasurer) DistanceMeasurer {
+	return DistanceFunc(func(a, b string) float64 {
+		var min float64
+		for i, dm := range dms {
+			if d := dm.Distance(a, b); i == 0 || d < min {
+				min = d
+			}
+		}
+		return min
+	})
+}
+
+// WeightedSum returns DistanceMeasurer of the sum of the distances of dms multiplied by weights.
+// It panics if the lengths of weights and dms differ.
+func WeightedSum(weights []float64, dms ...DistanceMeasurer) DistanceMeasurer {
+	if len(weights) != len(dms) {
+		panic("lsdp: WeightedSum: lengths of weights and dms differ")
+	}
+	return DistanceFunc(func(a, b string) float64 {
+		var sum float64
+		for i, dm := range dms {
+			sum += weights[i] * dm.Distance(a, b)
+		}
+		return sum
+	})
+}
+
+// Product returns DistanceMeasurer of the product of the distances of dms
+func Product(dms ...DistanceMeasurer) DistanceMeasurer {
+	return DistanceFunc(func(a, b string) float64 {
+		p := 1.0
+		for _, dm := range dms {
+			p *= dm.Distance(a, b)
+		}
+		return p
+	})
+}
+
+// Chain returns DistanceMeasurer that measures by first, and falls back to second when it's below threshold.
+// It suits a cheap first measurer screening pairs for an expensive but precise second one.
+func Chain(first, second DistanceMeasurer, threshold float64) DistanceMeasurer {
+	return DistanceFunc(func(a, b string) float64 {
+		if d := first.Distance(a, b); d >= threshold {
+			return d
+		}
+		return second.Distance(a, b)
+	})
+}
+
+// Switch returns DistanceMeasurer that measures by then if pred(a, b) is true, otherwise by otherwise
+func Switch(pred func(a, b string) bool, then, otherwise DistanceMeasurer) DistanceMeasurer {
+	return DistanceFunc(func(a, b string) float64 {
+		if pred(a, b) {
+			return then.Distance(a, b)
+		}
+		return otherwise.Distance(a, b)
+	})
+}
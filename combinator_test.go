@@ -0,0 +1,47 @@
+package lsdp
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCombinators(t *testing.T) {
+	std := Weights{Insert: 1, Delete: 1, Replace: 1}
+	wd := Weights{Insert: 10, Delete: 1, Replace: 0.1}
+	isASCII := func(a, b string) bool {
+		return utf8.RuneCountInString(a) == len(a) && utf8.RuneCountInString(b) == len(b)
+	}
+	testdata := []struct {
+		DM   DistanceMeasurer
+		A    string
+		B    string
+		Dist float64
+	}{
+		{Max(std, wd), "kitten", "shitting", 20.2},
+		{Max(wd, std), "abc", "abd", 1},
+		{Max(), "abc", "abd", 0},
+		{Min(std, wd), "kitten", "shitting", 4},
+		{Min(wd, std), "abc", "abd", 0.1},
+		{WeightedSum([]float64{0.5, 2}, std, wd), "kitten", "shitting", 42.4},
+		{Product(std, wd), "kitten", "shitting", 80.8},
+		{Product(), "a", "b", 1},
+		{Chain(Hamming{}, wd, 2), "abcd", "abcx", 0.1},
+		{Chain(Hamming{}, wd, 2), "abcd", "axyz", 3},
+		{Switch(isASCII, std, wd), "abc", "abd", 1},
+		{Switch(isASCII, std, wd), "こんにちは", "こんばんは", 0.2},
+	}
+	for i, td := range testdata {
+		if d := td.DM.Distance(td.A, td.B); !equals(d, td.Dist) {
+			t.Errorf(`%d: Distance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, td.Dist)
+		}
+	}
+}
+
+func TestWeightedSum_Panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WeightedSum() with mismatched lengths doesn't panic")
+		}
+	}()
+	WeightedSum([]float64{1}, Weights{1, 1, 1}, Hamming{})
+}
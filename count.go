@@ -75,7 +75,8 @@ func CountEdit(a, b string) (int, EditCounts) {
 	return int(result), cnts[len(cnts)-1]
 }
 
-// LevenshteinParam represents Levenshtein distance parameters for weighted by edit counts
+// LevenshteinParam represents Levenshtein distance parameters for weighted by edit counts.
+// The edits are counted along the unit cost path, use CountEditBy for the path minimizing the weighted cost.
 type LevenshteinParam struct {
 	Insert  float64
 	Delete  float64
@@ -0,0 +1,218 @@
+package lsdp
+
+import "errors"
+
+// ErrScriptMismatch is returned when an edit script doesn't match the string it's applied to
+var ErrScriptMismatch = errors.New("lsdp: edit script doesn't match the string")
+
+// Edit represents an editing means applied to a rune, Type is NONE for a matched rune
+type Edit struct {
+	Type EditType
+	Src  rune // the rune of the source, 0 if INSERT
+	Dest rune // the rune of the destination, 0 if DELETE
+}
+
+// EditScript represents a sequence of edits changing a string into another.
+// When es is from Align(cm, a, b), es.Apply(a) returns b and es.Invert().Apply(b) returns a.
+type EditScript []Edit
+
+// Align returns the weighted distance and the edit script along the path minimizing the cost of cm.
+// Ties are broken in favor of replace (or match), delete and insert in this order from the end of strings.
+func Align(cm CostModel, a, b string) (float64, EditScript) {
+	return alignRunes(cm, []rune(a), []rune(b))
+}
+
+func alignRunes(cm CostModel, ar, br []rune) (float64, EditScript) {
+	cost := costMatrix(cm, ar, br)
+
+	es := make(EditScript, 0, len(ar)+len(br))
+	i, j := len(ar), len(br)
+	for i > 0 || j > 0 {
+		t := backStep(cm, cost, ar, br, i, j)
+		es = append(es, newEdit(t, ar, br, i, j))
+		i, j = prevCell(t, i, j)
+	}
+	es.reverse()
+	return cost[len(ar)][len(br)], es
+}
+
+// CountEditBy aggregates edits along the path minimizing the weighted cost of cm
+func CountEditBy(cm CostModel, a, b string) (float64, EditCounts) {
+	d, es := Align(cm, a, b)
+	return d, es.Counts()
+}
+
+// Counts returns aggregating by editing types
+func (es EditScript) Counts() EditCounts {
+	var cnt EditCounts
+	for _, e := range es {
+		cnt[e.Type]++
+	}
+	return cnt
+}
+
+// RuneEditCounts represents aggregating edits by rune
+type RuneEditCounts struct {
+	Insert  map[rune]int
+	Delete  map[rune]int
+	Replace map[[2]rune]int
+}
+
+// RuneCounts returns aggregating edits by rune
+func (es EditScript) RuneCounts() RuneEditCounts {
+	rc := RuneEditCounts{
+		Insert:  make(map[rune]int),
+		Delete:  make(map[rune]int),
+		Replace: make(map[[2]rune]int),
+	}
+	for _, e := range es {
+		switch e.Type {
+		case INSERT:
+			rc.Insert[e.Dest]++
+		case DELETE:
+			rc.Delete[e.Src]++
+		case REPLACE:
+			rc.Replace[[2]rune{e.Src, e.Dest}]++
+		}
+	}
+	return rc
+}
+
+// Apply returns the string changed from a by the edit script
+func (es EditScript) Apply(a string) (string, error) {
+	ar := []rune(a)
+	out := make([]rune, 0, len(es))
+	var i int
+	for _, e := range es {
+		if e.Type != INSERT {
+			if i >= len(ar) || ar[i] != e.Src {
+				return "", ErrScriptMismatch
+			}
+			i++
+		}
+		if e.Type != DELETE {
+			out = append(out, e.Dest)
+		}
+	}
+	if i != len(ar) {
+		return "", ErrScriptMismatch
+	}
+	return string(out), nil
+}
+
+// Invert returns the edit script changing the destination back to the source
+func (es EditScript) Invert() EditScript {
+	inv := make(EditScript, len(es))
+	for i, e := range es {
+		t := e.Type
+		if t == INSERT {
+			t = DELETE
+		} else if t == DELETE {
+			t = INSERT
+		}
+		inv[i] = Edit{Type: t, Src: e.Dest, Dest: e.Src}
+	}
+	return inv
+}
+
+// Compose returns the edit script doing es and then next, that changes a to c when es changes a to b and next changes b to c
+func (es EditScript) Compose(next EditScript) (EditScript, error) {
+	comp := make(EditScript, 0, len(es)+len(next))
+	var i, j int
+	for i < len(es) || j < len(next) {
+		if i < len(es) && es[i].Type == DELETE {
+			comp = append(comp, es[i])
+			i++
+			continue
+		}
+		if j < len(next) && next[j].Type == INSERT {
+			comp = append(comp, next[j])
+			j++
+			continue
+		}
+		if i >= len(es) || j >= len(next) || es[i].Dest != next[j].Src {
+			return nil, ErrScriptMismatch
+		}
+
+		e, n := es[i], next[j]
+		i, j = i+1, j+1
+		switch {
+		case e.Type == INSERT && n.Type == DELETE:
+		case e.Type == INSERT:
+			comp = append(comp, Edit{Type: INSERT, Dest: n.Dest})
+		case n.Type == DELETE:
+			comp = append(comp, Edit{Type: DELETE, Src: e.Src})
+		case e.Src == n.Dest:
+			comp = append(comp, Edit{Type: NONE, Src: e.Src, Dest: n.Dest})
+		default:
+			comp = append(comp, Edit{Type: REPLACE, Src: e.Src, Dest: n.Dest})
+		}
+	}
+	return comp, nil
+}
+
+func (es EditScript) reverse() {
+	for i, j := 0, len(es)-1; i < j; i, j = i+1, j-1 {
+		es[i], es[j] = es[j], es[i]
+	}
+}
+
+// costMatrix returns the full DP matrix, cost[i][j] is the distance between ar[:i] and br[:j]
+func costMatrix(cm CostModel, ar, br []rune) [][]float64 {
+	cost := make([][]float64, len(ar)+1)
+	buf := make([]float64, (len(ar)+1)*(len(br)+1))
+	for i := range cost {
+		cost[i] = buf[i*(len(br)+1) : (i+1)*(len(br)+1)]
+	}
+	for i := 1; i < len(ar)+1; i++ {
+		cost[i][0] = cost[i-1][0] + cm.DeleteCost(ar[i-1])
+	}
+	for j := 1; j < len(br)+1; j++ {
+		cost[0][j] = cost[0][j-1] + cm.InsertCost(br[j-1])
+	}
+	for i := 1; i < len(ar)+1; i++ {
+		for j := 1; j < len(br)+1; j++ {
+			cost[i][j] = minCost(
+				cost[i-1][j-1]+cm.ReplaceCost(ar[i-1], br[j-1]),
+				cost[i-1][j]+cm.DeleteCost(ar[i-1]),
+				cost[i][j-1]+cm.InsertCost(br[j-1]))
+		}
+	}
+	return cost
+}
+
+// backStep returns the editing means of the canonical optimal step reaching the cell (i, j)
+func backStep(cm CostModel, cost [][]float64, ar, br []rune, i, j int) EditType {
+	if i > 0 && j > 0 && nearlyEqual(cost[i][j], cost[i-1][j-1]+cm.ReplaceCost(ar[i-1], br[j-1])) {
+		return REPLACE
+	}
+	if i > 0 && (j == 0 || nearlyEqual(cost[i][j], cost[i-1][j]+cm.DeleteCost(ar[i-1]))) {
+		return DELETE
+	}
+	return INSERT
+}
+
+// newEdit returns the edit of the step reaching the cell (i, j), replacing with the same rune is NONE
+func newEdit(t EditType, ar, br []rune, i, j int) Edit {
+	switch t {
+	case INSERT:
+		return Edit{Type: INSERT, Dest: br[j-1]}
+	case DELETE:
+		return Edit{Type: DELETE, Src: ar[i-1]}
+	}
+	if ar[i-1] == br[j-1] {
+		return Edit{Type: NONE, Src: ar[i-1], Dest: br[j-1]}
+	}
+	return Edit{Type: REPLACE, Src: ar[i-1], Dest: br[j-1]}
+}
+
+// prevCell returns the cell before the step of t reaching (i, j)
+func prevCell(t EditType, i, j int) (int, int) {
+	switch t {
+	case INSERT:
+		return i, j - 1
+	case DELETE:
+		return i - 1, j
+	}
+	return i - 1, j - 1
+}
@@ -0,0 +1,175 @@
+package lsdp
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestAlign(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		CM   CostModel
+		A    string
+		B    string
+		Dist float64
+		ES   EditScript
+	}{
+		{std, "", "", 0, EditScript{}},
+		{std, "a", "", 1, EditScript{{DELETE, 'a', 0}}},
+		{std, "", "a", 1, EditScript{{INSERT, 0, 'a'}}},
+		{std, "book", "back", 2, EditScript{{NONE, 'b', 'b'}, {REPLACE, 'o', 'a'}, {REPLACE, 'o', 'c'}, {NONE, 'k', 'k'}}},
+		{std, "ab", "ba", 2, EditScript{{REPLACE, 'a', 'b'}, {REPLACE, 'b', 'a'}}},
+		{Weights{Insert: 1, Delete: 1, Replace: 3}, "ab", "ba", 2, EditScript{{INSERT, 0, 'b'}, {NONE, 'a', 'a'}, {DELETE, 'b', 0}}},
+		{ByRune(&std).Replace("o", "a", 0.1), "book", "boa", 1.1, EditScript{{NONE, 'b', 'b'}, {NONE, 'o', 'o'}, {REPLACE, 'o', 'a'}, {DELETE, 'k', 0}}},
+	}
+	for i, td := range testdata {
+		d, es := Align(td.CM, td.A, td.B)
+		if !equals(d, td.Dist) {
+			t.Errorf(`%d: Align("%s", "%s") distance = %f, want %f`, i, td.A, td.B, d, td.Dist)
+		}
+		if !equalsScript(es, td.ES) {
+			t.Errorf(`%d: Align("%s", "%s") = %v, want %v`, i, td.A, td.B, es, td.ES)
+		}
+		if wd := td.CM.Distance(td.A, td.B); !equals(d, wd) {
+			t.Errorf(`%d: Align("%s", "%s") distance = %f, Distance() = %f`, i, td.A, td.B, d, wd)
+		}
+	}
+}
+
+func equalsScript(a, b EditScript) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCountEditBy(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		CM   CostModel
+		A    string
+		B    string
+		Dist float64
+		Edit EditCounts
+	}{
+		{std, "", "", 0, EditCounts{0, 0, 0, 0}},
+		{std, "book", "back", 2, EditCounts{0, 0, 2, 2}},
+		{std, "こんにちは", "こんばんは", 2, EditCounts{0, 0, 2, 3}},
+		{Weights{Insert: 1, Delete: 1, Replace: 3}, "book", "back", 4, EditCounts{2, 2, 0, 2}},
+		{Weights{Insert: 0.1, Delete: 1, Replace: 1}, "book", "board", 2.1, EditCounts{1, 0, 2, 2}},
+		{ByRune(&std).Delete("ok", 0.1).Insert("a", 0.1), "book", "boa", 0.3, EditCounts{1, 2, 0, 2}},
+	}
+	for i, td := range testdata {
+		d, cnt := CountEditBy(td.CM, td.A, td.B)
+		if !equals(d, td.Dist) || cnt != td.Edit {
+			t.Errorf(`%d: CountEditBy("%s", "%s") = %f, %v, want %f, %v`, i, td.A, td.B, d, cnt, td.Dist, td.Edit)
+		}
+	}
+}
+
+func TestEditScript_RuneCounts(t *testing.T) {
+	_, es := Align(Weights{1, 1, 1}, "kitten", "sitting")
+	rc := es.RuneCounts()
+	if len(rc.Insert) != 1 || rc.Insert['g'] != 1 {
+		t.Errorf("RuneCounts().Insert = %v, want map[g:1]", rc.Insert)
+	}
+	if len(rc.Delete) != 0 {
+		t.Errorf("RuneCounts().Delete = %v, want empty", rc.Delete)
+	}
+	if len(rc.Replace) != 2 || rc.Replace[[2]rune{'k', 's'}] != 1 || rc.Replace[[2]rune{'e', 'i'}] != 1 {
+		t.Errorf("RuneCounts().Replace = %v, want k->s and e->i", rc.Replace)
+	}
+}
+
+func TestEditScript_Apply(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		A string
+		B string
+	}{
+		{"", ""},
+		{"", "abc"},
+		{"abc", ""},
+		{"kitten", "sitting"},
+		{"こんにちは", "こんばんは"},
+	}
+	for i, td := range testdata {
+		_, es := Align(std, td.A, td.B)
+		if b, err := es.Apply(td.A); err != nil || b != td.B {
+			t.Errorf(`%d: Apply("%s") = "%s", %v, want "%s"`, i, td.A, b, err, td.B)
+		}
+		if a, err := es.Invert().Apply(td.B); err != nil || a != td.A {
+			t.Errorf(`%d: Invert().Apply("%s") = "%s", %v, want "%s"`, i, td.B, a, err, td.A)
+		}
+	}
+
+	_, es := Align(std, "book", "back")
+	for _, s := range []string{"", "boo", "books", "cook"} {
+		if _, err := es.Apply(s); err != ErrScriptMismatch {
+			t.Errorf(`Apply("%s") returns %v, want ErrScriptMismatch`, s, err)
+		}
+	}
+}
+
+func TestEditScript_Compose(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		A string
+		B string
+		C string
+	}{
+		{"", "", ""},
+		{"", "abc", ""},
+		{"abc", "", "xyz"},
+		{"kitten", "sitting", "sitter"},
+		{"book", "back", "books"},
+	}
+	for i, td := range testdata {
+		_, ab := Align(std, td.A, td.B)
+		_, bc := Align(std, td.B, td.C)
+		ac, err := ab.Compose(bc)
+		if err != nil {
+			t.Fatalf("%d: Compose() returns %v", i, err)
+		}
+		if c, err := ac.Apply(td.A); err != nil || c != td.C {
+			t.Errorf(`%d: Compose().Apply("%s") = "%s", %v, want "%s"`, i, td.A, c, err, td.C)
+		}
+	}
+
+	_, ab := Align(std, "book", "back")
+	_, xy := Align(std, "cook", "books")
+	if _, err := ab.Compose(xy); err != ErrScriptMismatch {
+		t.Errorf("Compose() of mismatched scripts returns %v, want ErrScriptMismatch", err)
+	}
+}
+
+func TestEditScript_RoundTrip(t *testing.T) {
+	wr := ByRune(&Weights{1, 1, 1}).Insert("a", 0.1).Delete("b", 0.2).Replace("c", "d", 0.3)
+	roundTrip := func(a, b, c string) bool {
+		_, ab := Align(wr, a, b)
+		_, bc := Align(wr, b, c)
+		if s, err := ab.Apply(a); err != nil || s != b {
+			return false
+		}
+		if s, err := ab.Invert().Apply(b); err != nil || s != a {
+			return false
+		}
+		if !equalsScript(ab.Invert().Invert(), ab) {
+			return false
+		}
+		ac, err := ab.Compose(bc)
+		if err != nil {
+			return false
+		}
+		s, err := ac.Apply(a)
+		return err == nil && s == c
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
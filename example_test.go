@@ -36,3 +36,37 @@ func ExampleDistanceAll() {
 	// Output:
 	// [5 5 2 8]
 }
+
+func ExampleSemiGlobal() {
+	std := lsdp.Weights{1, 1, 1}
+	m := lsdp.SemiGlobal(std, "error", "2020-01-01 [eror] disk full")
+	fmt.Println(m.Start, m.End, m.Dist)
+	// Output:
+	// 12 16 1
+}
+
+func ExampleEditScript_Inline() {
+	_, es := lsdp.Align(lsdp.Weights{1, 1, 1}, "kitten", "sitting")
+	fmt.Println(es.Inline())
+	// Output:
+	// [-k-]{+s+}itt[-e-]{+i+}n{+g+}
+}
+
+func ExampleJoin() {
+	std := lsdp.Weights{1, 1, 1}
+	crm := []string{"Acme Corp", "Globex", "Initech"}
+	billing := []string{"Initech Inc", "ACME Corp", "Globex"}
+	r, err := lsdp.Join(lsdp.Normalized(std), crm, billing, 0.4, lsdp.OneToOneOptimal)
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range r.Pairs {
+		fmt.Printf("%s = %s\n", crm[p.L], billing[p.R])
+	}
+	fmt.Println(r.UnmatchedL, r.UnmatchedR)
+	// Output:
+	// Acme Corp = ACME Corp
+	// Globex = Globex
+	// Initech = Initech Inc
+	// [] []
+}
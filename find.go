@@ -0,0 +1,128 @@
+package lsdp
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// FindAll returns every non-overlapping approximate occurrence of pattern in text within maxDist
+func FindAll(dm CostModel, pattern, text string, maxDist float64) []Match {
+	ms, _ := FindAllReader(dm, pattern, strings.NewReader(text), maxDist)
+	return ms
+}
+
+// FindAllReader returns every non-overlapping approximate occurrence of pattern in r within maxDist.
+// r is read as a stream of UTF-8 runes, so the text doesn't have to fit in memory.
+// Offsets of each Match are byte offsets from the beginning of r, which can be used to Seek or ReadAt back.
+func FindAllReader(dm CostModel, pattern string, r io.Reader, maxDist float64) ([]Match, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	f := newFinder(dm, pattern)
+	br := bufio.NewReader(r)
+	var ms []Match
+	for {
+		tr, size, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return ms, err
+		}
+		ms = f.push(tr, size, maxDist, ms)
+	}
+	return f.flush(maxDist, ms), nil
+}
+
+// finder scans a text stream keeping the best overlapping candidate until it's settled.
+// Positions in the DP are byte offsets, the pending runes are indexed by rune counts pos and base.
+type finder struct {
+	cm      CostModel
+	pattern []rune
+	col     []float64
+	start   []int
+	pos     int
+	base    int
+	offset  int
+	pending []rune
+	ends    []int // the byte offset at the end of each pending rune
+	found   bool
+	cand    Match
+	candPos int
+}
+
+func newFinder(cm CostModel, pattern string) *finder {
+	pr := []rune(pattern)
+	f := &finder{
+		cm:      cm,
+		pattern: pr,
+		col:     make([]float64, len(pr)+1),
+		start:   make([]int, len(pr)+1),
+	}
+	f.reset(0, 0)
+	return f
+}
+
+// reset restarts the DP at the rune count pos, which is the byte offset off
+func (f *finder) reset(pos, off int) {
+	f.pos = pos
+	f.col[0], f.start[0] = 0, off
+	for i := 1; i < len(f.col); i++ {
+		f.col[i] = f.col[i-1] + f.cm.DeleteCost(f.pattern[i-1])
+		f.start[i] = off
+	}
+}
+
+func (f *finder) push(tr rune, size int, maxDist float64, ms []Match) []Match {
+	f.offset += size
+	f.pending = append(f.pending, tr)
+	f.ends = append(f.ends, f.offset)
+	return f.drain(maxDist, ms)
+}
+
+// drain steps the DP over every pending rune
+func (f *finder) drain(maxDist float64, ms []Match) []Match {
+	for f.pos < f.base+len(f.pending) {
+		tr, end := f.pending[f.pos-f.base], f.ends[f.pos-f.base]
+		f.pos++
+		stepSemiGlobal(f.cm, f.pattern, tr, end, f.col, f.start)
+		m := Match{Start: f.start[len(f.pattern)], End: end, Dist: f.col[len(f.pattern)]}
+		if f.found && (m.Dist > maxDist || m.Start >= f.cand.End) {
+			ms = f.settle(ms)
+			continue
+		}
+		if m.Dist <= maxDist && m.Start < m.End && (!f.found || m.Dist < f.cand.Dist) {
+			f.found = true
+			f.cand, f.candPos = m, f.pos
+			f.trim(f.pos)
+		}
+		if !f.found {
+			f.trim(f.pos)
+		}
+	}
+	return ms
+}
+
+// settle emits the candidate and rewinds the DP to just after it
+func (f *finder) settle(ms []Match) []Match {
+	ms = append(ms, f.cand)
+	f.found = false
+	f.reset(f.candPos, f.cand.End)
+	return ms
+}
+
+// flush emits the remaining candidates at the end of the text
+func (f *finder) flush(maxDist float64, ms []Match) []Match {
+	for f.found {
+		ms = f.settle(ms)
+		ms = f.drain(maxDist, ms)
+	}
+	return ms
+}
+
+// trim discards pending runes before the rune count pos
+func (f *finder) trim(pos int) {
+	f.pending = f.pending[pos-f.base:]
+	f.ends = f.ends[pos-f.base:]
+	f.base = pos
+}
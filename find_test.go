@@ -0,0 +1,74 @@
+package lsdp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		Pattern string
+		Text    string
+		MaxDist float64
+		Want    []Match
+	}{
+		{"", "abc", 1, nil},
+		{"abc", "", 1, nil},
+		{"abc", "xxabcxxabdxxxyz", 0, []Match{{2, 5, 0}}},
+		{"abc", "xxabcxxabdxxxyz", 1, []Match{{2, 5, 0}, {7, 9, 1}}},
+		{"aa", "aaaaa", 0, []Match{{0, 2, 0}, {2, 4, 0}}},
+		{"error", "eror: x, error: y, errrr: z", 1, []Match{{0, 4, 1}, {9, 14, 0}, {19, 23, 1}}},
+		{"こんにちは", "こんにちは、こんばんは", 2, []Match{{0, 15, 0}, {18, 33, 2}}},
+	}
+	for i, td := range testdata {
+		ms := FindAll(std, td.Pattern, td.Text, td.MaxDist)
+		if len(ms) != len(td.Want) {
+			t.Errorf(`%d: FindAll("%s", "%s") = %v, want %v`, i, td.Pattern, td.Text, ms, td.Want)
+			continue
+		}
+		for j := range ms {
+			if ms[j] != td.Want[j] {
+				t.Errorf(`%d: FindAll("%s", "%s") = %v, want %v`, i, td.Pattern, td.Text, ms, td.Want)
+				break
+			}
+		}
+	}
+}
+
+func TestFindAll_Slice(t *testing.T) {
+	text := "こんにちは、ça va? こんばんは"
+	var found []string
+	for _, m := range FindAll(Weights{1, 1, 1}, "こんばんは", text, 2) {
+		found = append(found, text[m.Start:m.End])
+	}
+	if len(found) != 2 || found[0] != "こんにちは" || found[1] != "こんばんは" {
+		t.Errorf("FindAll() slices %q", found)
+	}
+}
+
+func TestFindAllReader(t *testing.T) {
+	std := Weights{1, 1, 1}
+	line := strings.Repeat("é", 100) + "panic: nil map" + strings.Repeat("y", 100) + "\n"
+	text := strings.Repeat(line, 1000)
+	ms, err := FindAllReader(std, "panik", strings.NewReader(text), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 1000 {
+		t.Fatalf("FindAllReader() found %d items, want 1000", len(ms))
+	}
+	// offsets are in bytes, so they can be used to read the occurrence back
+	r := strings.NewReader(text)
+	for i, m := range ms {
+		if m.Start != i*len(line)+200 || m.End != i*len(line)+204 || m.Dist != 1 {
+			t.Errorf("%d: FindAllReader() = %v", i, m)
+			break
+		}
+		b := make([]byte, m.End-m.Start)
+		if _, err := r.ReadAt(b, int64(m.Start)); err != nil || string(b) != "pani" {
+			t.Errorf("%d: ReadAt(%d) = %q, %v", i, m.Start, b, err)
+			break
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package lsdp
+
+import "sync"
+
+// Clone returns a deep copy of WeightsByRune
+func (wr *WeightsByRune) Clone() *WeightsByRune {
+	w := *wr.w
+	c := ByRune(&w)
+	for r, cost := range wr.insRune {
+		c.insRune[r] = cost
+	}
+	for r, cost := range wr.delRune {
+		c.delRune[r] = cost
+	}
+	for rs, cost := range wr.repRune {
+		c.repRune[rs] = cost
+	}
+	c.insClass = append([]classCost(nil), wr.insClass...)
+	c.delClass = append([]classCost(nil), wr.delClass...)
+	c.repClass = append([]classPairCost(nil), wr.repClass...)
+	return c
+}
+
+// Freeze returns the immutable copy of WeightsByRune, later changes of wr don't affect it
+func (wr *WeightsByRune) Freeze() *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: wr.Clone()}
+}
+
+// FrozenWeightsByRune represents immutable weighted levenshtein distance by rune, it's safe for concurrent use.
+// With... methods derive modified copies.
+type FrozenWeightsByRune struct {
+	wr        *WeightsByRune
+	asciiOnce sync.Once
+	ascii     *runeTable
+}
+
+// Thaw returns a mutable copy
+func (f *FrozenWeightsByRune) Thaw() *WeightsByRune {
+	return f.wr.Clone()
+}
+
+// WithInsert returns a copy with cost by insert rune
+func (f *FrozenWeightsByRune) WithInsert(runeGroup string, insCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().Insert(runeGroup, insCost)}
+}
+
+// WithDelete returns a copy with cost by delete rune
+func (f *FrozenWeightsByRune) WithDelete(runeGroup string, delCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().Delete(runeGroup, delCost)}
+}
+
+// WithReplace returns a copy with cost by replace rune
+func (f *FrozenWeightsByRune) WithReplace(runeGroupSrc, runeGroupDest string, repCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().Replace(runeGroupSrc, runeGroupDest, repCost)}
+}
+
+// WithInsertClass returns a copy with cost by insert rune class
+func (f *FrozenWeightsByRune) WithInsertClass(class RuneClass, insCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().InsertClass(class, insCost)}
+}
+
+// WithDeleteClass returns a copy with cost by delete rune class
+func (f *FrozenWeightsByRune) WithDeleteClass(class RuneClass, delCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().DeleteClass(class, delCost)}
+}
+
+// WithReplaceClass returns a copy with cost by replace rune class
+func (f *FrozenWeightsByRune) WithReplaceClass(classSrc, classDest RuneClass, repCost float64) *FrozenWeightsByRune {
+	return &FrozenWeightsByRune{wr: f.wr.Clone().ReplaceClass(classSrc, classDest, repCost)}
+}
+
+// Distance returns weighted levenshtein distance by rune.
+// ASCII strings are measured by the dense table of ASCII compiled at the first use.
+func (f *FrozenWeightsByRune) Distance(a, b string) float64 {
+	if isASCII(a) && isASCII(b) {
+		f.asciiOnce.Do(func() {
+			f.ascii = compileASCIITable(f.wr)
+		})
+		return f.ascii.distanceASCII(a, b)
+	}
+	return f.wr.Distance(a, b)
+}
+
+// DistanceBytes returns weighted levenshtein distance by byte, each byte is regarded as the rune of its value
+func (f *FrozenWeightsByRune) DistanceBytes(a, b []byte) float64 {
+	return f.wr.DistanceBytes(a, b)
+}
+
+// InsertCost returns the cost of inserting r
+func (f *FrozenWeightsByRune) InsertCost(r rune) float64 {
+	return f.wr.InsertCost(r)
+}
+
+// DeleteCost returns the cost of deleting r
+func (f *FrozenWeightsByRune) DeleteCost(r rune) float64 {
+	return f.wr.DeleteCost(r)
+}
+
+// ReplaceCost returns the cost of replacing src with dest
+func (f *FrozenWeightsByRune) ReplaceCost(src, dest rune) float64 {
+	return f.wr.ReplaceCost(src, dest)
+}
+
+// MaxDistance returns the maximum possible weighted levenshtein distance by rune between a and b
+func (f *FrozenWeightsByRune) MaxDistance(a, b string) float64 {
+	return f.wr.MaxDistance(a, b)
+}
+
+// Symmetric reports whether the rules by rune are symmetric, rules by RuneClass are never regarded as symmetric
+func (f *FrozenWeightsByRune) Symmetric() bool {
+	return f.wr.Symmetric()
+}
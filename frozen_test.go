@@ -0,0 +1,95 @@
+package lsdp
+
+import (
+	"sync"
+	"testing"
+	"unicode"
+)
+
+func TestWeightsByRune_Clone(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).Insert("a", 0.1).InsertClass(unicode.IsDigit, 0.2)
+	c := wr.Clone().Insert("a", 0.5).Insert("b", 0.5).InsertClass(unicode.IsSpace, 0.3)
+	std.Insert = 10
+
+	testdata := []struct {
+		WR   *WeightsByRune
+		B    string
+		Dist float64
+	}{
+		{wr, "a", 0.1},
+		{wr, "b", 10},
+		{wr, " ", 10},
+		{c, "a", 0.5},
+		{c, "b", 0.5},
+		{c, "1", 0.2},
+		{c, " ", 0.3},
+		{c, "c", 1},
+	}
+	for i, td := range testdata {
+		if d := td.WR.Distance("", td.B); !equals(d, td.Dist) {
+			t.Errorf(`%d: Distance("", "%s") = %f, want %f`, i, td.B, d, td.Dist)
+		}
+	}
+}
+
+func TestFrozenWeightsByRune(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).Insert("a", 0.1)
+	f := wr.Freeze()
+	wr.Insert("a", 0.5)
+	std.Insert = 10
+
+	derived := f.WithInsert("b", 0.2).
+		WithDelete("c", 0.3).
+		WithReplace("d", "e", 0.4).
+		WithInsertClass(unicode.IsDigit, 0.5).
+		WithDeleteClass(unicode.IsSpace, 0.6).
+		WithReplaceClass(unicode.IsUpper, unicode.IsUpper, 0.7)
+	testdata := []struct {
+		CM   CostModel
+		A    string
+		B    string
+		Dist float64
+	}{
+		{f, "", "a", 0.1},
+		{f, "", "b", 1},
+		{derived, "", "a", 0.1},
+		{derived, "", "b", 0.2},
+		{derived, "c", "", 0.3},
+		{derived, "d", "e", 0.4},
+		{derived, "", "1", 0.5},
+		{derived, " ", "", 0.6},
+		{derived, "A", "B", 0.7},
+	}
+	for i, td := range testdata {
+		if d := td.CM.Distance(td.A, td.B); !equals(d, td.Dist) {
+			t.Errorf(`%d: Distance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, td.Dist)
+		}
+	}
+
+	thawed := f.Thaw().Insert("a", 0.9)
+	if d := f.Distance("", "a"); !equals(d, 0.1) {
+		t.Errorf("Thaw() changes frozen: Distance() = %f, want 0.1", d)
+	}
+	if d := thawed.Distance("", "a"); !equals(d, 0.9) {
+		t.Errorf("thawed Distance() = %f, want 0.9", d)
+	}
+}
+
+func TestFrozenWeightsByRune_Concurrent(t *testing.T) {
+	f := ByRune(&Weights{1, 1, 1}).Freeze()
+	group := []string{"apple", "orange", "lemon", "water melon"}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				Nearest(f, "mon", group)
+				f.WithInsert(string(rune('a'+i)), 0.1).Distance("mon", "lemon")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,60 @@
+package lsdp
+
+// AlignLinear returns the weighted distance and the edit script along the path minimizing the cost of cm,
+// in O(len(a)+len(b)) memory by Hirschberg's divide and conquer.
+// Among co-optimal paths, it may return a different one from Align.
+func AlignLinear(cm CostModel, a, b string) (float64, EditScript) {
+	ar, br := []rune(a), []rune(b)
+	es := make(EditScript, 0, len(ar)+len(br))
+	return hirschberg(cm, ar, br, es)
+}
+
+// hirschberg appends the edit script from ar to br to es
+func hirschberg(cm CostModel, ar, br []rune, es EditScript) (float64, EditScript) {
+	if len(ar) <= 1 || len(br) == 0 {
+		d, sub := alignRunes(cm, ar, br)
+		return d, append(es, sub...)
+	}
+
+	mid := len(ar) / 2
+	forward := lastCostRow(cm, ar[:mid], br)
+	backward := lastCostRow(cm, reversed(ar[mid:]), reversed(br))
+	k := 0
+	for j := range forward {
+		if forward[j]+backward[len(br)-j] < forward[k]+backward[len(br)-k] {
+			k = j
+		}
+	}
+
+	d1, es := hirschberg(cm, ar[:mid], br[:k], es)
+	d2, es := hirschberg(cm, ar[mid:], br[k:], es)
+	return d1 + d2, es
+}
+
+// lastCostRow returns the distances between ar and each prefix of br
+func lastCostRow(cm CostModel, ar, br []rune) []float64 {
+	row := make([]float64, len(br)+1)
+	for j := 1; j < len(row); j++ {
+		row[j] = row[j-1] + cm.InsertCost(br[j-1])
+	}
+	for i := 1; i < len(ar)+1; i++ {
+		diagonal := row[0]
+		row[0] += cm.DeleteCost(ar[i-1])
+		for j := 1; j < len(row); j++ {
+			rep := diagonal + cm.ReplaceCost(ar[i-1], br[j-1])
+			del := row[j] + cm.DeleteCost(ar[i-1])
+			ins := row[j-1] + cm.InsertCost(br[j-1])
+			diagonal = row[j]
+			row[j] = minCost(rep, del, ins)
+		}
+	}
+	return row
+}
+
+func reversed(rs []rune) []rune {
+	rev := make([]rune, len(rs))
+	for i, r := range rs {
+		rev[len(rs)-1-i] = r
+	}
+	return rev
+}
@@ -0,0 +1,77 @@
+package lsdp
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAlignLinear(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&Weights{1, 1, 1}).Insert("a", 0.1).Delete("b", 0.2).Replace("c", "d", 0.3)
+	testdata := []struct {
+		CM CostModel
+		A  string
+		B  string
+	}{
+		{std, "", ""},
+		{std, "abc", ""},
+		{std, "", "abc"},
+		{std, "kitten", "sitting"},
+		{std, "こんにちは", "こんばんは"},
+		{Weights{Insert: 1, Delete: 1, Replace: 3}, "abcdef", "badcfe"},
+		{wr, "abcabcabc", "dadada"},
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		testdata = append(testdata, struct {
+			CM CostModel
+			A  string
+			B  string
+		}{wr, randomString(rnd, rnd.Intn(30)), randomString(rnd, rnd.Intn(30))})
+	}
+
+	for i, td := range testdata {
+		want, _ := Align(td.CM, td.A, td.B)
+		d, es := AlignLinear(td.CM, td.A, td.B)
+		if !nearlyEqual(d, want) {
+			t.Errorf(`%d: AlignLinear("%s", "%s") distance = %f, want %f`, i, td.A, td.B, d, want)
+		}
+		if b, err := es.Apply(td.A); err != nil || b != td.B {
+			t.Errorf(`%d: AlignLinear("%s", "%s").Apply() = "%s", %v`, i, td.A, td.B, b, err)
+		}
+		if c := scriptCost(td.CM, es); !nearlyEqual(c, want) {
+			t.Errorf(`%d: AlignLinear("%s", "%s") script costs %f, want %f`, i, td.A, td.B, c, want)
+		}
+	}
+}
+
+// randomString returns a string of n runes drawn from rnd, a small alphabet makes equal runes frequent
+func randomString(rnd *rand.Rand, n int) string {
+	rs := make([]rune, n)
+	for i := range rs {
+		rs[i] = rune('a' + rnd.Intn(4))
+	}
+	return string(rs)
+}
+
+func scriptCost(cm CostModel, es EditScript) float64 {
+	var c float64
+	for _, e := range es {
+		switch e.Type {
+		case INSERT:
+			c += cm.InsertCost(e.Dest)
+		case DELETE:
+			c += cm.DeleteCost(e.Src)
+		default:
+			c += cm.ReplaceCost(e.Src, e.Dest)
+		}
+	}
+	return c
+}
+
+func BenchmarkAlignLinear(b *testing.B) {
+	std := Weights{1, 1, 1}
+	for i := 0; i < b.N; i++ {
+		AlignLinear(std, "abababababababbaababababbababa", benchLongInput)
+	}
+}
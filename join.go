@@ -0,0 +1,222 @@
+package lsdp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// JoinMode represents how Join matches rows of 2 datasets
+type JoinMode int
+
+// Join modes, every matched pair is within the threshold
+const (
+	OneToOneGreedy  JoinMode = iota // each row is matched at most once, the closest pairs are taken first
+	OneToOneOptimal                 // each row is matched at most once, maximizing the number of pairs then minimizing the sum of distances (Hungarian method)
+	ManyToOne                       // each left row is matched to its nearest right row, a right row can be matched many times
+)
+
+var joinModeNames = [...]string{"one-to-one-greedy", "one-to-one-optimal", "many-to-one"}
+
+func (m JoinMode) String() string {
+	if m < 0 || int(m) >= len(joinModeNames) {
+		return fmt.Sprintf("JoinMode(%d)", int(m))
+	}
+	return joinModeNames[m]
+}
+
+// JoinPair represents a matched pair, left[L] and right[R]
+type JoinPair struct {
+	L    int
+	R    int
+	Dist float64
+}
+
+// JoinResult represents the result of Join.
+// Pairs are ordered by L, unmatched rows are indexes in ascending order.
+type JoinResult struct {
+	Pairs      []JoinPair
+	UnmatchedL []int
+	UnmatchedR []int
+}
+
+// Join matches rows of left and right whose distance measured by dm is threshold or less.
+// Ties are broken by the order of rows, except for which optimal assignment OneToOneOptimal picks.
+// It returns an error for an unknown mode.
+func Join(dm DistanceMeasurer, left, right []string, threshold float64, mode JoinMode) (JoinResult, error) {
+	if mode < 0 || int(mode) >= len(joinModeNames) {
+		return JoinResult{}, fmt.Errorf("lsdp: unknown join mode %v", mode)
+	}
+	m := CrossDistance(dm, left, right)
+	var pairs []JoinPair
+	switch mode {
+	case OneToOneGreedy:
+		pairs = joinGreedy(m, threshold)
+	case OneToOneOptimal:
+		pairs = joinOptimal(m, len(right), threshold)
+	case ManyToOne:
+		pairs = joinNearest(m, threshold)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].L < pairs[j].L
+	})
+
+	matchedL := make([]bool, len(left))
+	matchedR := make([]bool, len(right))
+	for _, p := range pairs {
+		matchedL[p.L], matchedR[p.R] = true, true
+	}
+	return JoinResult{
+		Pairs:      pairs,
+		UnmatchedL: unmatched(matchedL),
+		UnmatchedR: unmatched(matchedR),
+	}, nil
+}
+
+func unmatched(matched []bool) []int {
+	var idx []int
+	for i, ok := range matched {
+		if !ok {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// joinGreedy takes the closest pair among unmatched rows one by one
+func joinGreedy(m [][]float64, threshold float64) []JoinPair {
+	var cands []JoinPair
+	for i, row := range m {
+		for j, d := range row {
+			if d <= threshold {
+				cands = append(cands, JoinPair{L: i, R: j, Dist: d})
+			}
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		return cands[i].Dist < cands[j].Dist
+	})
+
+	var pairs []JoinPair
+	usedL, usedR := make(map[int]bool), make(map[int]bool)
+	for _, p := range cands {
+		if !usedL[p.L] && !usedR[p.R] {
+			usedL[p.L], usedR[p.R] = true, true
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
+
+// joinOptimal solves the assignment on the square matrix padded with zero cost rows or columns.
+// Pairs over threshold cost more than all pairs within it together, so they are taken only when nothing else is left.
+func joinOptimal(m [][]float64, cols int, threshold float64) []JoinPair {
+	n := len(m)
+	if cols > n {
+		n = cols
+	}
+	big := 1.0
+	for _, row := range m {
+		for _, d := range row {
+			if d <= threshold {
+				big += math.Abs(d)
+			}
+		}
+	}
+
+	cost := newMatrix(n, n)
+	for i, row := range m {
+		for j, d := range row {
+			if d <= threshold {
+				cost[i][j] = d
+			} else {
+				cost[i][j] = big
+			}
+		}
+	}
+
+	var pairs []JoinPair
+	for i, j := range hungarian(cost) {
+		if i < len(m) && j < cols && m[i][j] <= threshold {
+			pairs = append(pairs, JoinPair{L: i, R: j, Dist: m[i][j]})
+		}
+	}
+	return pairs
+}
+
+// joinNearest matches each left row to its nearest right row
+func joinNearest(m [][]float64, threshold float64) []JoinPair {
+	var pairs []JoinPair
+	for i, row := range m {
+		best := -1
+		for j, d := range row {
+			if d <= threshold && (best < 0 || d < row[best]) {
+				best = j
+			}
+		}
+		if best >= 0 {
+			pairs = append(pairs, JoinPair{L: i, R: best, Dist: row[best]})
+		}
+	}
+	return pairs
+}
+
+// hungarian returns the column assigned to each row of the square cost matrix minimizing the sum of costs.
+// It runs in O(n^3) time with potentials u and v of rows and columns.
+func hungarian(cost [][]float64) []int {
+	n := len(cost)
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1) // p[j] is the row (1-origin) assigned to the column j, 0 means none
+	way := make([]int, n+1)
+	minv := make([]float64, n+1)
+	used := make([]bool, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assign := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if p[j] > 0 {
+			assign[p[j]-1] = j - 1
+		}
+	}
+	return assign
+}
@@ -0,0 +1,125 @@
+package lsdp
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	std := Weights{1, 1, 1}
+	left := []string{"cat", "bat", "dog"}
+	right := []string{"cat", "cart", "bird"}
+	testdata := []struct {
+		Mode JoinMode
+		Want JoinResult
+	}{
+		{OneToOneGreedy, JoinResult{
+			Pairs:      []JoinPair{{0, 0, 0}},
+			UnmatchedL: []int{1, 2},
+			UnmatchedR: []int{1, 2},
+		}},
+		{OneToOneOptimal, JoinResult{
+			Pairs:      []JoinPair{{0, 1, 1}, {1, 0, 1}},
+			UnmatchedL: []int{2},
+			UnmatchedR: []int{2},
+		}},
+		{ManyToOne, JoinResult{
+			Pairs:      []JoinPair{{0, 0, 0}, {1, 0, 1}},
+			UnmatchedL: []int{2},
+			UnmatchedR: []int{1, 2},
+		}},
+	}
+	for _, td := range testdata {
+		if r, err := Join(std, left, right, 1, td.Mode); err != nil || !reflect.DeepEqual(r, td.Want) {
+			t.Errorf("Join(%v) = %+v, %v, want %+v", td.Mode, r, err, td.Want)
+		}
+	}
+
+	for _, mode := range []JoinMode{OneToOneGreedy, OneToOneOptimal, ManyToOne} {
+		if r, _ := Join(std, nil, right, 1, mode); len(r.Pairs) != 0 || len(r.UnmatchedL) != 0 || !reflect.DeepEqual(r.UnmatchedR, []int{0, 1, 2}) {
+			t.Errorf("Join(%v) with empty left = %+v", mode, r)
+		}
+		if r, _ := Join(std, left, nil, 1, mode); len(r.Pairs) != 0 || !reflect.DeepEqual(r.UnmatchedL, []int{0, 1, 2}) || len(r.UnmatchedR) != 0 {
+			t.Errorf("Join(%v) with empty right = %+v", mode, r)
+		}
+	}
+
+	for _, mode := range []JoinMode{-1, ManyToOne + 1} {
+		if _, err := Join(std, left, right, 1, mode); err == nil {
+			t.Errorf("Join(%v) returns no error", mode)
+		}
+	}
+}
+
+func TestJoin_Optimal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	cm := Weights{Insert: 1, Delete: 1.5, Replace: 0.7}
+	for n := 0; n < 200; n++ {
+		left := make([]string, rnd.Intn(6))
+		right := make([]string, rnd.Intn(6))
+		for i := range left {
+			left[i] = randomString(rnd, rnd.Intn(4)+1)
+		}
+		for i := range right {
+			right[i] = randomString(rnd, rnd.Intn(4)+1)
+		}
+		threshold := rnd.Float64() * 3
+
+		r, _ := Join(cm, left, right, threshold, OneToOneOptimal)
+		var sum float64
+		usedR := make(map[int]bool)
+		for _, p := range r.Pairs {
+			if p.Dist > threshold || usedR[p.R] {
+				t.Fatalf("%v %v: invalid pair %+v", left, right, p)
+			}
+			usedR[p.R] = true
+			sum += p.Dist
+		}
+		count, best := bruteForceJoin(CrossDistance(cm, left, right), threshold, 0, make(map[int]bool))
+		if len(r.Pairs) != count || !equals(sum, best) {
+			t.Errorf("%v %v %f: %d pairs cost %f, want %d pairs cost %f", left, right, threshold, len(r.Pairs), sum, count, best)
+		}
+		if len(r.Pairs)+len(r.UnmatchedL) != len(left) || len(r.Pairs)+len(r.UnmatchedR) != len(right) {
+			t.Errorf("%v %v: unmatched rows %+v", left, right, r)
+		}
+
+		greedy, _ := Join(cm, left, right, threshold, OneToOneGreedy)
+		if len(greedy.Pairs) > count {
+			t.Errorf("%v %v: greedy found %d pairs more than optimal %d", left, right, len(greedy.Pairs), count)
+		}
+	}
+}
+
+// bruteForceJoin returns the maximum number of pairs from row i and the minimum sum of their distances
+func bruteForceJoin(m [][]float64, threshold float64, i int, usedR map[int]bool) (int, float64) {
+	if i == len(m) {
+		return 0, 0
+	}
+	count, sum := bruteForceJoin(m, threshold, i+1, usedR)
+	for j, d := range m[i] {
+		if d > threshold || usedR[j] {
+			continue
+		}
+		usedR[j] = true
+		c, s := bruteForceJoin(m, threshold, i+1, usedR)
+		usedR[j] = false
+		if c+1 > count || c+1 == count && s+d < sum {
+			count, sum = c+1, s+d
+		}
+	}
+	return count, sum
+}
+
+func TestJoinMode_String(t *testing.T) {
+	for mode, want := range map[JoinMode]string{
+		OneToOneGreedy:  "one-to-one-greedy",
+		OneToOneOptimal: "one-to-one-optimal",
+		ManyToOne:       "many-to-one",
+		JoinMode(9):     "JoinMode(9)",
+	} {
+		if s := mode.String(); s != want {
+			t.Errorf("String() = %s, want %s", s, want)
+		}
+	}
+}
@@ -3,11 +3,21 @@ Package lsdp provides Weighted Levenshtein distance and its extended interface
 */
 package lsdp
 
+import "unicode/utf8"
+
 // DistanceMeasurer provides measurement of the distance between 2 strings
 type DistanceMeasurer interface {
 	Distance(string, string) float64
 }
 
+// CostModel provides the cost of each editing means by rune
+type CostModel interface {
+	DistanceMeasurer
+	InsertCost(r rune) float64
+	DeleteCost(r rune) float64
+	ReplaceCost(src, dest rune) float64
+}
+
 // Lsd returns standard Levenshtein distance
 func Lsd(a, b string) int {
 	wd := &Weights{1, 1, 1}
@@ -23,15 +33,39 @@ type Weights struct {
 
 // Distance returns weighted Levenshtein distance
 func (w Weights) Distance(a, b string) float64 {
-	result := accumulateCost(a, b, func(_, _ int, ar, br rune, diagonal, above, left float64) (float64, float64, float64) {
-		if ar != br {
-			diagonal += w.Replace
-		}
-		above += w.Insert
-		left += w.Delete
-		return diagonal, above, left
-	}, minCost)
-	return result
+	return accumulateCost(a, b, w.cellCost, minCost)
+}
+
+// DistanceBytes returns weighted Levenshtein distance by byte, without decoding UTF-8
+func (w Weights) DistanceBytes(a, b []byte) float64 {
+	return accumulateCostBytes(a, b, w.cellCost, minCost)
+}
+
+func (w Weights) cellCost(_, _ int, ar, br rune, diagonal, above, left float64) (float64, float64, float64) {
+	if ar != br {
+		diagonal += w.Replace
+	}
+	above += w.Insert
+	left += w.Delete
+	return diagonal, above, left
+}
+
+// InsertCost returns the cost of inserting r
+func (w Weights) InsertCost(_ rune) float64 {
+	return w.Insert
+}
+
+// DeleteCost returns the cost of deleting r
+func (w Weights) DeleteCost(_ rune) float64 {
+	return w.Delete
+}
+
+// ReplaceCost returns the cost of replacing src with dest
+func (w Weights) ReplaceCost(src, dest rune) float64 {
+	if src == dest {
+		return 0
+	}
+	return w.Replace
 }
 
 // ByRune returns weighted levenshtein distance by rune
@@ -45,34 +79,79 @@ func ByRune(w *Weights) *WeightsByRune {
 }
 
 // WeightsByRune represents weighted levenshtein distance by rune
+//
+// The cost of each edit is decided by the first matching rule in this order:
+// rules by rune (Insert, Delete, Replace), rules by RuneClass (InsertClass, DeleteClass, ReplaceClass)
+// where the latest added class rule wins, and the base Weights.
+//
+// Changing rules during measuring from other goroutines is a data race, use Freeze for concurrent use.
 type WeightsByRune struct {
-	w       *Weights
-	insRune map[rune]float64
-	delRune map[rune]float64
-	repRune map[[2]rune]float64
+	w        *Weights
+	insRune  map[rune]float64
+	delRune  map[rune]float64
+	repRune  map[[2]rune]float64
+	insClass []classCost
+	delClass []classCost
+	repClass []classPairCost
 }
 
-// Distance returns weighted levenshtein distance by rune
+// Distance returns weighted levenshtein distance by rune.
+// Long strings are measured by a dense table of their alphabet, ASCII strings skip decoding runes.
 func (wr *WeightsByRune) Distance(a, b string) float64 {
-	ret := accumulateCost(a, b, func(_, _ int, ar, br rune, diagonal, above, left float64) (float64, float64, float64) {
-		if rw, ok := wr.repRune[[2]rune{ar, br}]; ok {
-			diagonal += rw
-		} else if ar != br {
-			diagonal += wr.w.Replace
+	if len(a)*len(b) >= denseTableThreshold {
+		return tableDistance(wr, a, b)
+	}
+	return accumulateCost(a, b, wr.cellCost, minCost)
+}
+
+// DistanceBytes returns weighted levenshtein distance by byte, each byte is regarded as the rune of its value
+func (wr *WeightsByRune) DistanceBytes(a, b []byte) float64 {
+	return accumulateCostBytes(a, b, wr.cellCost, minCost)
+}
+
+func (wr *WeightsByRune) cellCost(_, _ int, ar, br rune, diagonal, above, left float64) (float64, float64, float64) {
+	return diagonal + wr.ReplaceCost(ar, br), above + wr.InsertCost(br), left + wr.DeleteCost(ar)
+}
+
+// InsertCost returns the cost of inserting r
+func (wr *WeightsByRune) InsertCost(r rune) float64 {
+	if rw, ok := wr.insRune[r]; ok {
+		return rw
+	}
+	for i := len(wr.insClass) - 1; i >= 0; i-- {
+		if wr.insClass[i].class(r) {
+			return wr.insClass[i].cost
 		}
-		if rw, ok := wr.insRune[br]; ok {
-			above += rw
-		} else {
-			above += wr.w.Insert
+	}
+	return wr.w.Insert
+}
+
+// DeleteCost returns the cost of deleting r
+func (wr *WeightsByRune) DeleteCost(r rune) float64 {
+	if rw, ok := wr.delRune[r]; ok {
+		return rw
+	}
+	for i := len(wr.delClass) - 1; i >= 0; i-- {
+		if wr.delClass[i].class(r) {
+			return wr.delClass[i].cost
 		}
-		if rw, ok := wr.delRune[ar]; ok {
-			left += rw
-		} else {
-			left += wr.w.Delete
+	}
+	return wr.w.Delete
+}
+
+// ReplaceCost returns the cost of replacing src with dest
+func (wr *WeightsByRune) ReplaceCost(src, dest rune) float64 {
+	if rw, ok := wr.repRune[[2]rune{src, dest}]; ok {
+		return rw
+	} else if src == dest {
+		return 0
+	}
+	for i := len(wr.repClass) - 1; i >= 0; i-- {
+		if c := wr.repClass[i]; c.src(src) && c.dest(dest) {
+			return c.cost
 		}
-		return diagonal, above, left
-	}, minCost)
-	return ret
+	}
+	return wr.w.Replace
 }
 
 // Insert specify cost by insert rune
@@ -101,25 +180,51 @@ func (wr *WeightsByRune) Replace(runeGroupSrc, runeGroupDest string, repCost flo
 	return wr
 }
 
-// Normalized returns what wrapped the DistanceMeasurer with nomalize by string length
-func Normalized(dm DistanceMeasurer) DistanceMeasurer {
-	return normalizedParam{wrapped: dm}
+// InsertClass specify cost by insert rune class
+func (wr *WeightsByRune) InsertClass(class RuneClass, insCost float64) *WeightsByRune {
+	wr.insClass = append(wr.insClass, classCost{class, insCost})
+	return wr
+}
+
+// DeleteClass specify cost by delete rune class
+func (wr *WeightsByRune) DeleteClass(class RuneClass, delCost float64) *WeightsByRune {
+	wr.delClass = append(wr.delClass, classCost{class, delCost})
+	return wr
+}
+
+// ReplaceClass specify cost by replace rune class, it doesn't apply to replacing a rune with itself
+func (wr *WeightsByRune) ReplaceClass(classSrc, classDest RuneClass, repCost float64) *WeightsByRune {
+	wr.repClass = append(wr.repClass, classPairCost{classSrc, classDest, repCost})
+	return wr
+}
+
+// Normalized returns what wrapped the DistanceMeasurer with nomalize by string length.
+// by optionally selects the Normalization strategy, the default is ByMaxLength.
+func Normalized(dm DistanceMeasurer, by ...Normalization) DistanceMeasurer {
+	p := normalizedParam{wrapped: dm, by: ByMaxLength}
+	if len(by) > 0 {
+		p.by = by[len(by)-1]
+	}
+	return p
 }
 
 type normalizedParam struct {
 	wrapped DistanceMeasurer
+	by      Normalization
 }
 
 func (p normalizedParam) Distance(a, b string) float64 {
-	d := p.wrapped.Distance(a, b)
-	l := len([]rune(a))
-	if lb := len([]rune(b)); l < lb {
-		l = lb
+	if p.by == MarzalVidal {
+		if cm, ok := p.wrapped.(CostModel); ok {
+			return marzalVidal(cm, a, b)
+		}
 	}
+	d := p.wrapped.Distance(a, b)
+	l := p.denominator(a, b)
 	if l == 0 {
 		return d
 	}
-	return d / float64(l)
+	return d / l
 }
 
 // DistanceFunc type is an adapter to allow the use of ordinary functions as DistanceMeasurer.
@@ -134,7 +239,12 @@ func (f DistanceFunc) Distance(a, b string) float64 {
 type costFunc func(ai, bi int, ar, br rune, diagonal, above, left float64) (rep, ins, del float64)
 type minFunc func(a, b, c float64) (min float64)
 
+// accumulateCost calls costf by rune, and by byte without converting to runes if both strings are ASCII
 func accumulateCost(a, b string, costf costFunc, min minFunc) float64 {
+	if isASCII(a) && isASCII(b) {
+		return accumulateCostASCII(a, b, costf, min)
+	}
+
 	ar, br := []rune(a), []rune(b)
 	costRow := make([]float64, len(ar)+1)
 	for i := 1; i < len(costRow); i++ {
@@ -155,6 +265,40 @@ func accumulateCost(a, b string, costf costFunc, min minFunc) float64 {
 	return costRow[len(costRow)-1]
 }
 
+// accumulateCostASCII measures ASCII strings by byte, which is the same as by rune
+func accumulateCostASCII(a, b string, costf costFunc, min minFunc) float64 {
+	return accumulateCostBytes([]byte(a), []byte(b), costf, min)
+}
+
+func accumulateCostBytes(a, b []byte, costf costFunc, min minFunc) float64 {
+	costRow := make([]float64, len(a)+1)
+	for i := 1; i < len(costRow); i++ {
+		_, _, costRow[i] = costf(i, 0, rune(a[i-1]), 0, 0, 0, costRow[i-1])
+	}
+
+	var left float64
+	for bc := 1; bc < len(b)+1; bc++ {
+		_, left, _ = costf(0, bc, 0, rune(b[bc-1]), 0, costRow[0], 0)
+		for i := 1; i < len(costRow); i++ {
+			rep, ins, del := costf(i, bc, rune(a[i-1]), rune(b[bc-1]), costRow[i-1], costRow[i], left)
+			costRow[i-1] = left
+			left = min(rep, ins, del)
+		}
+		costRow[len(costRow)-1] = left
+	}
+
+	return costRow[len(costRow)-1]
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 func minCost(a, b, c float64) (min float64) {
 	min = a
 	if b < min {
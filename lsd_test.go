@@ -3,6 +3,7 @@ package lsdp
 import (
 	"math"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +55,63 @@ func TestWeights_Distance(t *testing.T) {
 	}
 }
 
+func TestWeights_DistanceBytes(t *testing.T) {
+	testdata := []struct {
+		W    Weights
+		A    string
+		B    string
+		Cost float64
+	}{
+		{Weights{Insert: 1, Delete: 1, Replace: 1}, "", "", 0},
+		{Weights{Insert: 1, Delete: 1, Replace: 1}, "back", "books", 3},
+		{Weights{Insert: 0, Delete: 1, Replace: 1}, "back", "books", 2},
+		{Weights{Insert: 1, Delete: 1, Replace: 1}, "こんにちは", "こんばんは", 3},
+		{Weights{Insert: 1, Delete: 1, Replace: 1}, "\x00\xff", "\xff", 1},
+	}
+
+	for i, d := range testdata {
+		if c := d.W.DistanceBytes([]byte(d.A), []byte(d.B)); !equals(c, d.Cost) {
+			t.Errorf(`%d: DistanceBytes("%s", "%s") = %f, want %f`, i, d.A, d.B, c, d.Cost)
+		}
+	}
+
+	wr := ByRune(&Weights{1, 1, 1}).Insert("a", 0.1).Replace("b", "c", 0.01)
+	if c := wr.DistanceBytes([]byte("bab"), []byte("cac")); !equals(c, 0.02) {
+		t.Errorf(`wr.DistanceBytes("bab", "cac") = %f, want 0.02`, c)
+	}
+}
+
+func TestIsASCII(t *testing.T) {
+	testdata := []struct {
+		S    string
+		Want bool
+	}{
+		{"", true},
+		{"abc 123\x7f", true},
+		{"abc\x80", false},
+		{"こんにちは", false},
+	}
+	for _, td := range testdata {
+		if b := isASCII(td.S); b != td.Want {
+			t.Errorf(`isASCII("%s") = %v, want %v`, td.S, b, td.Want)
+		}
+	}
+}
+
+func TestAccumulateCostASCII(t *testing.T) {
+	std := Weights{1, 1, 1}
+	a, b := strings.Repeat("kitten", 20), strings.Repeat("sitting", 20)
+	if d, want := accumulateCostASCII(a, b, std.cellCost, minCost), std.DistanceBytes([]byte(a), []byte(b)); !equals(d, want) {
+		t.Errorf("accumulateCostASCII() = %f, want %f", d, want)
+	}
+	// only the cost row is allocated, ASCII strings are not copied into byte slices
+	if n := testing.AllocsPerRun(100, func() {
+		accumulateCostASCII(a, b, std.cellCost, minCost)
+	}); n != 1 {
+		t.Errorf("accumulateCostASCII() allocates %f times, want 1", n)
+	}
+}
+
 func TestWeightsByRune_Distance(t *testing.T) {
 	std := Weights{1, 1, 1}
 	wrIns := ByRune(&std).Insert("a", 0.1)
@@ -174,3 +232,13 @@ func BenchmarkWeightsDistance1(b *testing.B) { benchWeightsDist(b, "a") }
 func BenchmarkWeightsDistance2(b *testing.B) {
 	benchWeightsDist(b, "abababababababababababababababababababababab")
 }
+
+func BenchmarkWeightsDistanceBytes(b *testing.B) {
+	w := Weights{Insert: 1, Delete: 1, Replace: 1}
+	s, long := []byte("abababababababababababababababababababababab"), []byte(benchLongInput)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.DistanceBytes(s, long)
+		w.DistanceBytes(long, s)
+	}
+}
@@ -0,0 +1,268 @@
+package lsdp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// Symmetric is implemented by DistanceMeasurer declaring Distance(a, b) always equals Distance(b, a)
+type Symmetric interface {
+	Symmetric() bool
+}
+
+func isSymmetric(dm DistanceMeasurer) bool {
+	s, ok := dm.(Symmetric)
+	return ok && s.Symmetric()
+}
+
+// Symmetric reports whether the insert and delete costs are the same
+func (w Weights) Symmetric() bool {
+	return w.Insert == w.Delete
+}
+
+// Symmetric reports whether the rules by rune are symmetric, rules by RuneClass are never regarded as symmetric
+func (wr *WeightsByRune) Symmetric() bool {
+	if !wr.w.Symmetric() || len(wr.insClass) > 0 || len(wr.delClass) > 0 || len(wr.repClass) > 0 {
+		return false
+	}
+	if len(wr.insRune) != len(wr.delRune) {
+		return false
+	}
+	for r, c := range wr.insRune {
+		if dc, ok := wr.delRune[r]; !ok || dc != c {
+			return false
+		}
+	}
+	for k, c := range wr.repRune {
+		if rc, ok := wr.repRune[[2]rune{k[1], k[0]}]; !ok || rc != c {
+			return false
+		}
+	}
+	return true
+}
+
+// Symmetric reports whether the cache shares entries of swapped pairs
+func (c *CachedMeasurer) Symmetric() bool {
+	return c.opts.Symmetric
+}
+
+// Symmetric reports whether the wrapped DistanceMeasurer is symmetric
+func (p normalizedParam) Symmetric() bool {
+	return isSymmetric(p.wrapped)
+}
+
+// Symmetric returns true
+func (Jaro) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (JaroWinkler) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (Hamming) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (LCS) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (QGram) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (Cosine) Symmetric() bool { return true }
+
+// Symmetric returns true
+func (Jaccard) Symmetric() bool { return true }
+
+// DistanceMatrix returns the matrix of distances between each pair of strs, m[i][j] = dm.Distance(strs[i], strs[j]).
+// It computes only a half of the matrix if dm is Symmetric.
+func DistanceMatrix(dm DistanceMeasurer, strs []string) [][]float64 {
+	m := newMatrix(len(strs), len(strs))
+	sym := isSymmetric(dm)
+	parallelRows(len(strs), func(i int) {
+		for j, s := range strs {
+			if sym && j < i {
+				continue
+			}
+			m[i][j] = dm.Distance(strs[i], s)
+			if sym {
+				m[j][i] = m[i][j]
+			}
+		}
+	})
+	return m
+}
+
+// CrossDistance returns the matrix of distances from as to bs, m[i][j] = dm.Distance(as[i], bs[j])
+func CrossDistance(dm DistanceMeasurer, as, bs []string) [][]float64 {
+	m := newMatrix(len(as), len(bs))
+	parallelRows(len(as), func(i int) {
+		for j, s := range bs {
+			m[i][j] = dm.Distance(as[i], s)
+		}
+	})
+	return m
+}
+
+func newMatrix(rows, cols int) [][]float64 {
+	buf := make([]float64, rows*cols)
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = buf[i*cols : (i+1)*cols]
+	}
+	return m
+}
+
+// parallelRows calls f with each row index by a pool of GOMAXPROCS workers
+func parallelRows(rows int, f func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < rows; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// WriteMatrixCSV writes the matrix as CSV, one row per line
+func WriteMatrixCSV(w io.Writer, m [][]float64) error {
+	cw := csv.NewWriter(w)
+	for _, row := range m {
+		rec := make([]string, len(row))
+		for j, d := range row {
+			rec[j] = strconv.FormatFloat(d, 'g', -1, 64)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var matrixMagic = [4]byte{'L', 'S', 'D', 'M'}
+
+const matrixSymmetricFlag = 1
+
+// maxEmptyMatrixRows limits rows of a matrix without columns, which have no payload to check the header against
+const maxEmptyMatrixRows = 1 << 20
+
+// WriteMatrix writes the matrix in a compact binary format which ReadMatrix reads.
+// A symmetric matrix is stored as its upper triangle.
+func WriteMatrix(w io.Writer, m [][]float64) error {
+	rows, cols := len(m), 0
+	if rows > 0 {
+		cols = len(m[0])
+	}
+	var flags uint8
+	if isSymmetricMatrix(m) {
+		flags |= matrixSymmetricFlag
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(matrixMagic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, struct {
+		Flags      uint8
+		Rows, Cols uint32
+	}{flags, uint32(rows), uint32(cols)}); err != nil {
+		return err
+	}
+	var b [8]byte
+	for i, row := range m {
+		if len(row) != cols {
+			return errors.New("lsdp: WriteMatrix: rows have different lengths")
+		}
+		for j, d := range row {
+			if flags&matrixSymmetricFlag != 0 && j < i {
+				continue
+			}
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(d))
+			if _, err := bw.Write(b[:]); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadMatrix reads the matrix written by WriteMatrix
+func ReadMatrix(r io.Reader) ([][]float64, error) {
+	br := bufio.NewReader(r)
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != matrixMagic {
+		return nil, errors.New("lsdp: ReadMatrix: not a matrix format")
+	}
+	var header struct {
+		Flags      uint8
+		Rows, Cols uint32
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+
+	sym := header.Flags&matrixSymmetricFlag != 0
+	rows, cols := int(header.Rows), int(header.Cols)
+	if sym && rows != cols {
+		return nil, errors.New("lsdp: ReadMatrix: symmetric matrix is not square")
+	}
+	if cols == 0 && rows > maxEmptyMatrixRows {
+		return nil, errors.New("lsdp: ReadMatrix: too many empty rows")
+	}
+
+	// rows grow as their values are read, so a forged header can't allocate more than r holds
+	m := [][]float64{}
+	var b [8]byte
+	for i := 0; i < rows; i++ {
+		row := []float64{}
+		for j := 0; j < cols; j++ {
+			if sym && j < i {
+				row = append(row, m[j][i])
+				continue
+			}
+			if _, err := io.ReadFull(br, b[:]); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+			row = append(row, math.Float64frombits(binary.LittleEndian.Uint64(b[:])))
+		}
+		m = append(m, row)
+	}
+	return m, nil
+}
+
+func isSymmetricMatrix(m [][]float64) bool {
+	for _, row := range m {
+		if len(row) != len(m) {
+			return false
+		}
+	}
+	for i, row := range m {
+		for j := i + 1; j < len(row); j++ {
+			if row[j] != m[j][i] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package lsdp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+func equalsMatrix(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if !equals(a[i][j], b[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	strs := []string{"book", "back", "cook", ""}
+	sym := Weights{1, 1, 1}
+	asym := Weights{Insert: 1, Delete: 0.5, Replace: 1}
+	testdata := []struct {
+		DM   DistanceMeasurer
+		Want [][]float64
+	}{
+		{sym, [][]float64{
+			{0, 2, 1, 4},
+			{2, 0, 3, 4},
+			{1, 3, 0, 4},
+			{4, 4, 4, 0},
+		}},
+		{asym, [][]float64{
+			{0, 2, 1, 2},
+			{2, 0, 3, 2},
+			{1, 3, 0, 2},
+			{4, 4, 4, 0},
+		}},
+	}
+	for i, td := range testdata {
+		if m := DistanceMatrix(td.DM, strs); !equalsMatrix(m, td.Want) {
+			t.Errorf("%d: DistanceMatrix() = %v, want %v", i, m, td.Want)
+		}
+	}
+
+	if m := DistanceMatrix(sym, nil); len(m) != 0 {
+		t.Errorf("DistanceMatrix(nil) = %v, want empty", m)
+	}
+}
+
+func TestCrossDistance(t *testing.T) {
+	std := Weights{1, 1, 1}
+	m := CrossDistance(std, []string{"book", "cook"}, []string{"back", "", "books"})
+	want := [][]float64{
+		{2, 4, 1},
+		{3, 4, 2},
+	}
+	if !equalsMatrix(m, want) {
+		t.Errorf("CrossDistance() = %v, want %v", m, want)
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		DM   DistanceMeasurer
+		Want bool
+	}{
+		{std, true},
+		{Weights{1, 2, 1}, false},
+		{ByRune(&std).Insert("a", 0.1).Delete("a", 0.1).Replace("a", "b", 0.5).Replace("b", "a", 0.5), true},
+		{ByRune(&std).Insert("a", 0.1), false},
+		{ByRune(&std).Replace("a", "b", 0.5), false},
+		{Normalized(std), true},
+		{Cached(std, CacheOptions{Symmetric: true}), true},
+		{Cached(std, CacheOptions{}), false},
+		{Jaro{}, true},
+		{DistanceFunc(std.Distance), false},
+	}
+	for i, td := range testdata {
+		if b := isSymmetric(td.DM); b != td.Want {
+			t.Errorf("%d: isSymmetric(%T) = %v, want %v", i, td.DM, b, td.Want)
+		}
+	}
+}
+
+func TestWriteMatrixCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMatrixCSV(&buf, [][]float64{{0, 0.5}, {1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if s := buf.String(); s != "0,0.5\n1,2\n" {
+		t.Errorf("WriteMatrixCSV() writes %q", s)
+	}
+}
+
+func TestWriteMatrix(t *testing.T) {
+	std := Weights{1, 1, 1}
+	strs := []string{"book", "back", "cook", "", "こんにちは"}
+	testdata := []struct {
+		M    [][]float64
+		Size int
+	}{
+		{DistanceMatrix(std, strs), 13 + 8*15},
+		{CrossDistance(std, strs[:2], strs), 13 + 8*10},
+		{DistanceMatrix(Weights{1, 2, 1}, strs), 13 + 8*25},
+		{[][]float64{}, 13},
+	}
+	for i, td := range testdata {
+		var buf bytes.Buffer
+		if err := WriteMatrix(&buf, td.M); err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if buf.Len() != td.Size {
+			t.Errorf("%d: WriteMatrix() writes %d bytes, want %d bytes", i, buf.Len(), td.Size)
+		}
+		m, err := ReadMatrix(&buf)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !equalsMatrix(m, td.M) {
+			t.Errorf("%d: ReadMatrix() = %v, want %v", i, m, td.M)
+		}
+	}
+
+	if _, err := ReadMatrix(strings.NewReader("XXXX")); err == nil {
+		t.Errorf("ReadMatrix() of broken data returns no error")
+	}
+	if err := WriteMatrix(&bytes.Buffer{}, [][]float64{{1, 2}, {3}}); err == nil {
+		t.Errorf("WriteMatrix() of ragged matrix returns no error")
+	}
+}
+
+func TestReadMatrix_BrokenHeader(t *testing.T) {
+	header := func(flags uint8, rows, cols uint32, payload int) []byte {
+		b := make([]byte, 13+payload)
+		copy(b, "LSDM")
+		b[4] = flags
+		binary.LittleEndian.PutUint32(b[5:], rows)
+		binary.LittleEndian.PutUint32(b[9:], cols)
+		return b
+	}
+	testdata := [][]byte{
+		header(1, 2, 1, 16),
+		header(1, 1, 2, 16),
+		header(0, 2, 2, 8*3),
+		header(0, math.MaxUint32, math.MaxUint32, 8),
+		header(1, math.MaxUint32, math.MaxUint32, 0),
+		header(0, math.MaxUint32, 0, 0),
+		header(0, 2, 2, 8*4)[:10],
+	}
+	for i, b := range testdata {
+		if m, err := ReadMatrix(bytes.NewReader(b)); err == nil {
+			t.Errorf("%d: ReadMatrix() = %v, want error", i, m)
+		}
+	}
+
+	if m, err := ReadMatrix(bytes.NewReader(header(0, 3, 0, 0))); err != nil || len(m) != 3 {
+		t.Errorf("ReadMatrix() of 3x0 matrix = %v, %v", m, err)
+	}
+}
+
+func BenchmarkDistanceMatrix(b *testing.B) {
+	std := Weights{1, 1, 1}
+	for i := 0; i < b.N; i++ {
+		DistanceMatrix(std, benchInputStrings)
+	}
+}
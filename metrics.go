@@ -0,0 +1,289 @@
+package lsdp
+
+import "math"
+
+// Jaro represents Jaro distance, 1 - Jaro similarity
+type Jaro struct{}
+
+// Distance returns Jaro distance
+func (Jaro) Distance(a, b string) float64 {
+	return 1 - jaroSimilarity([]rune(a), []rune(b))
+}
+
+// MaxDistance returns the maximum possible Jaro distance
+func (Jaro) MaxDistance(_, _ string) float64 {
+	return 1
+}
+
+// JaroWinkler represents Jaro-Winkler distance, 1 - Jaro-Winkler similarity.
+// The zero value uses PrefixScale 0.1 and BoostThreshold 0.7.
+// PrefixScale is clamped to [0, 0.25], a larger scale would make the distance negative.
+// NoBoost disables the prefix boost, which is the same as Jaro distance.
+type JaroWinkler struct {
+	PrefixScale    float64
+	BoostThreshold float64
+	NoBoost        bool
+}
+
+// Distance returns Jaro-Winkler distance
+func (jw JaroWinkler) Distance(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	sim := jaroSimilarity(ar, br)
+
+	p, th := jw.PrefixScale, jw.BoostThreshold
+	if p == 0 {
+		p = 0.1
+	} else if p < 0 {
+		p = 0
+	} else if p > 0.25 {
+		p = 0.25
+	}
+	if th == 0 {
+		th = 0.7
+	}
+	if jw.NoBoost || sim <= th {
+		return 1 - sim
+	}
+	var l int
+	for l < 4 && l < len(ar) && l < len(br) && ar[l] == br[l] {
+		l++
+	}
+	return 1 - (sim + float64(l)*p*(1-sim))
+}
+
+// MaxDistance returns the maximum possible Jaro-Winkler distance
+func (JaroWinkler) MaxDistance(_, _ string) float64 {
+	return 1
+}
+
+func jaroSimilarity(ar, br []rune) float64 {
+	if len(ar) == 0 && len(br) == 0 {
+		return 1
+	} else if len(ar) == 0 || len(br) == 0 {
+		return 0
+	}
+
+	window := len(ar)
+	if len(br) > window {
+		window = len(br)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ar))
+	matchedB := make([]bool, len(br))
+	var matches int
+	for i, r := range ar {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(br) {
+			hi = len(br)
+		}
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && br[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	var transpositions, j int
+	for i, r := range ar {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if r != br[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ar)) + m/float64(len(br)) + (m-float64(transpositions/2))/m) / 3
+}
+
+// Hamming represents Hamming distance, the difference of rune lengths counts as mismatches
+type Hamming struct{}
+
+// Distance returns Hamming distance
+func (Hamming) Distance(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	if len(ar) > len(br) {
+		ar, br = br, ar
+	}
+	d := len(br) - len(ar)
+	for i, r := range ar {
+		if r != br[i] {
+			d++
+		}
+	}
+	return float64(d)
+}
+
+// MaxDistance returns the maximum possible Hamming distance, the longer rune length
+func (Hamming) MaxDistance(a, b string) float64 {
+	l := len([]rune(a))
+	if lb := len([]rune(b)); l < lb {
+		l = lb
+	}
+	return float64(l)
+}
+
+// LCS represents the distance by the longest common subsequence, len(a) + len(b) - 2*len(lcs)
+type LCS struct{}
+
+// Distance returns LCS distance
+func (LCS) Distance(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	row := make([]int, len(br)+1)
+	for i := 1; i < len(ar)+1; i++ {
+		var diagonal int
+		for j := 1; j < len(row); j++ {
+			above := row[j]
+			if ar[i-1] == br[j-1] {
+				row[j] = diagonal + 1
+			} else if row[j-1] > row[j] {
+				row[j] = row[j-1]
+			}
+			diagonal = above
+		}
+	}
+	return float64(len(ar) + len(br) - 2*row[len(br)])
+}
+
+// MaxDistance returns the maximum possible LCS distance, the sum of rune lengths
+func (LCS) MaxDistance(a, b string) float64 {
+	return float64(len([]rune(a)) + len([]rune(b)))
+}
+
+// QGram represents q-gram distance, the L1 distance between q-gram profiles.
+// Q less than 1 means 2, a string shorter than Q is a q-gram as is.
+type QGram struct {
+	Q int
+}
+
+// Distance returns q-gram distance
+func (qg QGram) Distance(a, b string) float64 {
+	pa, pb := qgramProfile(a, qg.Q), qgramProfile(b, qg.Q)
+	var d int
+	for g, ca := range pa {
+		if cb := pb[g]; ca > cb {
+			d += ca - cb
+		} else {
+			d += cb - ca
+		}
+	}
+	for g, cb := range pb {
+		if _, ok := pa[g]; !ok {
+			d += cb
+		}
+	}
+	return float64(d)
+}
+
+// MaxDistance returns the maximum possible q-gram distance, the sum of the numbers of q-grams
+func (qg QGram) MaxDistance(a, b string) float64 {
+	return float64(qgramCount(a, qg.Q) + qgramCount(b, qg.Q))
+}
+
+// Cosine represents cosine distance between q-gram profiles, 1 - cosine similarity.
+// Q less than 1 means 2, a string shorter than Q is a q-gram as is.
+type Cosine struct {
+	Q int
+}
+
+// Distance returns cosine distance
+func (c Cosine) Distance(a, b string) float64 {
+	pa, pb := qgramProfile(a, c.Q), qgramProfile(b, c.Q)
+	if len(pa) == 0 && len(pb) == 0 {
+		return 0
+	} else if len(pa) == 0 || len(pb) == 0 {
+		return 1
+	}
+	var dot, na, nb float64
+	for g, ca := range pa {
+		dot += float64(ca * pb[g])
+		na += float64(ca * ca)
+	}
+	for _, cb := range pb {
+		nb += float64(cb * cb)
+	}
+	d := 1 - dot/(math.Sqrt(na)*math.Sqrt(nb))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// MaxDistance returns the maximum possible cosine distance
+func (Cosine) MaxDistance(_, _ string) float64 {
+	return 1
+}
+
+// Jaccard represents Jaccard distance between q-gram sets, 1 - |intersection|/|union|.
+// Q less than 1 means 2, a string shorter than Q is a q-gram as is.
+type Jaccard struct {
+	Q int
+}
+
+// Distance returns Jaccard distance
+func (jc Jaccard) Distance(a, b string) float64 {
+	pa, pb := qgramProfile(a, jc.Q), qgramProfile(b, jc.Q)
+	if len(pa) == 0 && len(pb) == 0 {
+		return 0
+	}
+	var inter int
+	for g := range pa {
+		if _, ok := pb[g]; ok {
+			inter++
+		}
+	}
+	return 1 - float64(inter)/float64(len(pa)+len(pb)-inter)
+}
+
+// MaxDistance returns the maximum possible Jaccard distance
+func (Jaccard) MaxDistance(_, _ string) float64 {
+	return 1
+}
+
+func qgramProfile(s string, q int) map[string]int {
+	if q < 1 {
+		q = 2
+	}
+	rs := []rune(s)
+	p := make(map[string]int)
+	if len(rs) == 0 {
+		return p
+	} else if len(rs) < q {
+		p[s]++
+		return p
+	}
+	for i := 0; i+q <= len(rs); i++ {
+		p[string(rs[i:i+q])]++
+	}
+	return p
+}
+
+func qgramCount(s string, q int) int {
+	if q < 1 {
+		q = 2
+	}
+	l := len([]rune(s))
+	if l == 0 {
+		return 0
+	} else if l < q {
+		return 1
+	}
+	return l - q + 1
+}
@@ -0,0 +1,61 @@
+package lsdp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	testdata := []struct {
+		DM   DistanceMeasurer
+		A    string
+		B    string
+		Dist float64
+	}{
+		{Jaro{}, "", "", 0},
+		{Jaro{}, "abc", "", 1},
+		{Jaro{}, "abc", "xyz", 1},
+		{Jaro{}, "MARTHA", "MARHTA", 1 - 0.9444444444444445},
+		{Jaro{}, "DIXON", "DICKSONX", 1 - 0.7666666666666666},
+		{JaroWinkler{}, "MARTHA", "MARHTA", 1 - 0.9611111111111111},
+		{JaroWinkler{}, "DIXON", "DICKSONX", 1 - 0.8133333333333332},
+		{JaroWinkler{PrefixScale: 0.2, BoostThreshold: 0.9}, "DIXON", "DICKSONX", 1 - 0.7666666666666666},
+		{JaroWinkler{PrefixScale: 0.5}, "abcdx", "abcdy", 0},
+		{JaroWinkler{PrefixScale: 0.25}, "abcdx", "abcdy", 0},
+		{JaroWinkler{PrefixScale: -1}, "MARTHA", "MARHTA", 1 - 0.9444444444444445},
+		{JaroWinkler{NoBoost: true}, "MARTHA", "MARHTA", 1 - 0.9444444444444445},
+		{Hamming{}, "karolin", "kathrin", 3},
+		{Hamming{}, "abc", "abcde", 2},
+		{Hamming{}, "", "", 0},
+		{LCS{}, "kitten", "sitting", 5},
+		{LCS{}, "", "abc", 3},
+		{LCS{}, "こんにちは", "こんばんは", 4},
+		{QGram{Q: 2}, "abcd", "abce", 2},
+		{QGram{}, "a", "b", 2},
+		{QGram{}, "aaa", "aa", 1},
+		{Cosine{Q: 2}, "abc", "abc", 0},
+		{Cosine{Q: 2}, "abc", "xyz", 1},
+		{Cosine{Q: 2}, "abcd", "abce", 1.0 / 3},
+		{Cosine{}, "", "", 0},
+		{Jaccard{Q: 2}, "abcd", "abce", 0.5},
+		{Jaccard{}, "", "a", 1},
+		{Jaccard{}, "", "", 0},
+	}
+	for i, td := range testdata {
+		if d := td.DM.Distance(td.A, td.B); math.Abs(d-td.Dist) > 1e-12 {
+			t.Errorf(`%d: %T.Distance("%s", "%s") = %v, want %v`, i, td.DM, td.A, td.B, d, td.Dist)
+		}
+		if max := td.DM.(DistanceBounder).MaxDistance(td.A, td.B); td.Dist > max {
+			t.Errorf(`%d: %T.MaxDistance("%s", "%s") = %v, less than %v`, i, td.DM, td.A, td.B, max, td.Dist)
+		}
+	}
+}
+
+func TestMetrics_Nearest(t *testing.T) {
+	group := []string{"apple", "orange", "lemon", "water melon"}
+	for _, dm := range []DistanceMeasurer{Jaro{}, JaroWinkler{}, LCS{}, QGram{}, Cosine{}, Jaccard{}} {
+		if s, _ := Nearest(dm, "lemmon", group); s != "lemon" {
+			t.Errorf(`Nearest(%T, "lemmon") = "%s", want "lemon"`, dm, s)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package lsdp
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normalization represents a strategy of Normalized
+type Normalization int
+
+// Normalization strategies, each divides the distance by what is noted.
+// The strategies fall back to ByMaxLength when the wrapped DistanceMeasurer doesn't provide what they need.
+const (
+	ByMaxLength       Normalization = iota // the longer rune length
+	BySumLength                            // the sum of rune lengths
+	ByAlignmentLength                      // the number of edits and matches along the optimal path, needs CostModel
+	ByMaxCost                              // the maximum possible distance of the pair, needs DistanceBounder
+	MarzalVidal                            // the length of the path minimizing cost/length (Marzal & Vidal, 1993), needs CostModel
+)
+
+// Normalizations is the list of all Normalization strategies
+var Normalizations = []Normalization{ByMaxLength, BySumLength, ByAlignmentLength, ByMaxCost, MarzalVidal}
+
+var normalizationNames = [...]string{"max-length", "sum-length", "alignment-length", "max-cost", "marzal-vidal"}
+
+func (n Normalization) String() string {
+	if n < 0 || int(n) >= len(normalizationNames) {
+		return fmt.Sprintf("Normalization(%d)", int(n))
+	}
+	return normalizationNames[n]
+}
+
+// ParseNormalization returns Normalization by its name, e.g. "sum-length"
+func ParseNormalization(name string) (Normalization, error) {
+	for i, s := range normalizationNames {
+		if s == name {
+			return Normalization(i), nil
+		}
+	}
+	return ByMaxLength, fmt.Errorf("lsdp: unknown normalization %q", name)
+}
+
+func (p normalizedParam) denominator(a, b string) float64 {
+	switch p.by {
+	case BySumLength:
+		return float64(len([]rune(a)) + len([]rune(b)))
+	case ByAlignmentLength:
+		if cm, ok := p.wrapped.(CostModel); ok {
+			_, l := alignmentLength(cm, a, b)
+			return float64(l)
+		}
+	case ByMaxCost:
+		if db, ok := p.wrapped.(DistanceBounder); ok {
+			return db.MaxDistance(a, b)
+		}
+	}
+	l := len([]rune(a))
+	if lb := len([]rune(b)); l < lb {
+		l = lb
+	}
+	return float64(l)
+}
+
+// alignmentLength returns the distance and the length of the optimal path, ties prefer the longer path
+func alignmentLength(cm CostModel, a, b string) (float64, int) {
+	ar, br := []rune(a), []rune(b)
+	costRow := make([]float64, len(ar)+1)
+	lenRow := make([]int, len(ar)+1)
+	for i := 1; i < len(costRow); i++ {
+		costRow[i] = costRow[i-1] + cm.DeleteCost(ar[i-1])
+		lenRow[i] = i
+	}
+
+	for j := 1; j < len(br)+1; j++ {
+		diagonal, diagonalLen := costRow[0], lenRow[0]
+		costRow[0] += cm.InsertCost(br[j-1])
+		lenRow[0] = j
+		for i := 1; i < len(costRow); i++ {
+			rep, repLen := diagonal+cm.ReplaceCost(ar[i-1], br[j-1]), diagonalLen+1
+			ins, insLen := costRow[i]+cm.InsertCost(br[j-1]), lenRow[i]+1
+			del, delLen := costRow[i-1]+cm.DeleteCost(ar[i-1]), lenRow[i-1]+1
+			diagonal, diagonalLen = costRow[i], lenRow[i]
+
+			min, minLen := rep, repLen
+			if ins < min || ins == min && insLen > minLen {
+				min, minLen = ins, insLen
+			}
+			if del < min || del == min && delLen > minLen {
+				min, minLen = del, delLen
+			}
+			costRow[i], lenRow[i] = min, minLen
+		}
+	}
+	return costRow[len(ar)], lenRow[len(ar)]
+}
+
+// marzalVidal returns the minimum of cost(P)/length(P) over every edit path P from a to b
+func marzalVidal(cm CostModel, a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	if len(ar) == 0 && len(br) == 0 {
+		return 0
+	}
+	// row[i][l] is the minimum cost of the paths of length l to the cell i
+	maxLen := len(ar) + len(br)
+	newRow := func() [][]float64 {
+		row := make([][]float64, len(ar)+1)
+		for i := range row {
+			row[i] = make([]float64, maxLen+1)
+			for l := range row[i] {
+				row[i][l] = math.Inf(1)
+			}
+		}
+		return row
+	}
+	prev, cur := newRow(), newRow()
+	prev[0][0] = 0
+	for i := 1; i < len(ar)+1; i++ {
+		prev[i][i] = prev[i-1][i-1] + cm.DeleteCost(ar[i-1])
+	}
+
+	for j := 1; j < len(br)+1; j++ {
+		for i := range cur {
+			for l := range cur[i] {
+				cur[i][l] = math.Inf(1)
+			}
+		}
+		for l := 1; l < maxLen+1; l++ {
+			cur[0][l] = prev[0][l-1] + cm.InsertCost(br[j-1])
+		}
+		for i := 1; i < len(cur); i++ {
+			for l := 1; l < maxLen+1; l++ {
+				rep := prev[i-1][l-1] + cm.ReplaceCost(ar[i-1], br[j-1])
+				ins := prev[i][l-1] + cm.InsertCost(br[j-1])
+				del := cur[i-1][l-1] + cm.DeleteCost(ar[i-1])
+				cur[i][l] = minCost(rep, ins, del)
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	min := math.Inf(1)
+	for l, c := range prev[len(ar)] {
+		if l > 0 && c/float64(l) < min {
+			min = c / float64(l)
+		}
+	}
+	return min
+}
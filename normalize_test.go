@@ -0,0 +1,55 @@
+package lsdp
+
+import "testing"
+
+func TestNormalized_By(t *testing.T) {
+	std := Weights{1, 1, 1}
+	costlyRep := Weights{Insert: 1, Delete: 1, Replace: 3}
+	var constd DistanceFunc = func(_, _ string) float64 {
+		return 1
+	}
+	testdata := []struct {
+		DM   DistanceMeasurer
+		By   Normalization
+		A    string
+		B    string
+		Dist float64
+	}{
+		{std, ByMaxLength, "ab", "ba", 1},
+		{std, BySumLength, "ab", "ba", 0.5},
+		{std, ByAlignmentLength, "ab", "ba", 2.0 / 3},
+		{std, ByMaxCost, "ab", "ba", 1},
+		{std, MarzalVidal, "ab", "ba", 2.0 / 3},
+		{std, ByMaxLength, "", "", 0},
+		{std, BySumLength, "", "", 0},
+		{std, ByAlignmentLength, "", "", 0},
+		{std, ByMaxCost, "", "", 0},
+		{std, MarzalVidal, "", "", 0},
+		{std, BySumLength, "abc", "", 1},
+		{std, MarzalVidal, "abc", "", 1},
+		{costlyRep, ByMaxLength, "a", "b", 2},
+		{costlyRep, ByAlignmentLength, "a", "b", 1},
+		{costlyRep, ByMaxCost, "a", "b", 1},
+		{costlyRep, MarzalVidal, "a", "b", 1},
+		{costlyRep, MarzalVidal, "abcd", "abxd", 0.4},
+		{constd, ByAlignmentLength, "ab", "ba", 0.5},
+		{constd, ByMaxCost, "ab", "ba", 0.5},
+		{constd, MarzalVidal, "ab", "ba", 0.5},
+	}
+	for i, td := range testdata {
+		if d := Normalized(td.DM, td.By).Distance(td.A, td.B); !equals(d, td.Dist) {
+			t.Errorf(`%d: Normalized(%v).Distance("%s", "%s") = %f, want %f`, i, td.By, td.A, td.B, d, td.Dist)
+		}
+	}
+}
+
+func TestParseNormalization(t *testing.T) {
+	for _, n := range Normalizations {
+		if p, err := ParseNormalization(n.String()); err != nil || p != n {
+			t.Errorf(`ParseNormalization("%s") = %v, %v`, n, p, err)
+		}
+	}
+	if _, err := ParseNormalization("unknown"); err == nil {
+		t.Errorf(`ParseNormalization("unknown") returns no error`)
+	}
+}
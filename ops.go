@@ -1,29 +1,50 @@
 package lsdp
 
-// Nearest returns the nearest string in the specified distance measurer
+import "sort"
+
+// Result represents a found string and its distance
+type Result struct {
+	Str  string
+	Dist float64
+}
+
+// Nearest returns the nearest string in the specified distance measurer, ties are broken by the order of strs.
+// If dm is LowerBounder or BoundedDistancer, strings which can't beat the nearest so far are skipped.
 func Nearest(dm DistanceMeasurer, orig string, strs []string) (nearest string, distance float64) {
-	type result struct {
-		str  string
-		dist float64
+	dists, oks := newPruner(dm, 1).scan(orig, strs)
+	idx := -1
+	for i, ok := range oks {
+		if ok && (idx < 0 || dists[i] < distance) {
+			distance = dists[i]
+			idx = i
+		}
 	}
-
-	ch := make(chan result)
-	for _, s := range strs {
-		go func(s string) {
-			ch <- result{s, dm.Distance(orig, s)}
-		}(s)
+	if idx >= 0 {
+		nearest = strs[idx]
 	}
+	return
+}
 
-	initFlag := true
-	for range strs {
-		r := <-ch
-		if initFlag || r.dist < distance {
-			distance = r.dist
-			nearest = r.str
-			initFlag = false
+// NearestK returns the k nearest strings ordered by distance, ties are broken by the order of strs.
+// If dm is LowerBounder or BoundedDistancer, strings which can't beat the k-th nearest so far are skipped.
+func NearestK(dm DistanceMeasurer, orig string, strs []string, k int) []Result {
+	if k <= 0 {
+		return []Result{}
+	}
+	dists, oks := newPruner(dm, k).scan(orig, strs)
+	rs := []Result{}
+	for i, s := range strs {
+		if oks[i] {
+			rs = append(rs, Result{Str: s, Dist: dists[i]})
 		}
 	}
-	return
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].Dist < rs[j].Dist
+	})
+	if k < len(rs) {
+		rs = rs[:k]
+	}
+	return rs
 }
 
 // DistanceAll returns slice of distance orig to each strs
@@ -0,0 +1,123 @@
+package lsdp
+
+import (
+	"math"
+	"math/big"
+)
+
+// CountOptimal returns the weighted distance and the number of edit paths achieving it
+func CountOptimal(cm CostModel, a, b string) (float64, *big.Int) {
+	ar, br := []rune(a), []rune(b)
+	cost := costMatrix(cm, ar, br)
+	cnts := make([][]big.Int, len(ar)+1)
+	for i := range cnts {
+		cnts[i] = make([]big.Int, len(br)+1)
+	}
+	cnts[0][0].SetInt64(1)
+	for i := range cnts {
+		for j := range cnts[i] {
+			for _, t := range optimalSteps(cm, cost, ar, br, i, j) {
+				pi, pj := prevCell(t, i, j)
+				cnts[i][j].Add(&cnts[i][j], &cnts[pi][pj])
+			}
+		}
+	}
+	return cost[len(ar)][len(br)], &cnts[len(ar)][len(br)]
+}
+
+// optimalSteps returns the editing means of every optimal step reaching the cell (i, j) in the canonical order
+func optimalSteps(cm CostModel, cost [][]float64, ar, br []rune, i, j int) []EditType {
+	var ts []EditType
+	if i > 0 && j > 0 && nearlyEqual(cost[i][j], cost[i-1][j-1]+cm.ReplaceCost(ar[i-1], br[j-1])) {
+		ts = append(ts, REPLACE)
+	}
+	if i > 0 && nearlyEqual(cost[i][j], cost[i-1][j]+cm.DeleteCost(ar[i-1])) {
+		ts = append(ts, DELETE)
+	}
+	if j > 0 && nearlyEqual(cost[i][j], cost[i][j-1]+cm.InsertCost(br[j-1])) {
+		ts = append(ts, INSERT)
+	}
+	return ts
+}
+
+// nearlyEqual compares costs summed in different orders
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(a))
+}
+
+// OptimalIter enumerates the edit scripts of co-optimal paths lazily.
+// The first one is the canonical path which Align returns.
+type OptimalIter struct {
+	cm      CostModel
+	ar, br  []rune
+	cost    [][]float64
+	limit   int
+	count   int
+	started bool
+	stack   []optimalFrame
+}
+
+type optimalFrame struct {
+	i, j    int
+	choices []EditType
+	k       int
+}
+
+// Optimal returns the iterator of co-optimal edit scripts from a to b, at most limit scripts if limit is more than 0
+func Optimal(cm CostModel, a, b string, limit int) *OptimalIter {
+	ar, br := []rune(a), []rune(b)
+	return &OptimalIter{
+		cm:    cm,
+		ar:    ar,
+		br:    br,
+		cost:  costMatrix(cm, ar, br),
+		limit: limit,
+	}
+}
+
+// Distance returns the weighted distance of the paths
+func (it *OptimalIter) Distance() float64 {
+	return it.cost[len(it.ar)][len(it.br)]
+}
+
+// Next returns the next edit script, false if there is no more
+func (it *OptimalIter) Next() (EditScript, bool) {
+	if it.limit > 0 && it.count >= it.limit {
+		return nil, false
+	}
+	if !it.started {
+		it.started = true
+		it.push(len(it.ar), len(it.br))
+	} else if !it.advance() {
+		return nil, false
+	}
+
+	for top := it.stack[len(it.stack)-1]; len(top.choices) > 0; top = it.stack[len(it.stack)-1] {
+		it.push(prevCell(top.choices[top.k], top.i, top.j))
+	}
+	it.count++
+
+	es := make(EditScript, 0, len(it.stack)-1)
+	for _, f := range it.stack[:len(it.stack)-1] {
+		es = append(es, newEdit(f.choices[f.k], it.ar, it.br, f.i, f.j))
+	}
+	es.reverse()
+	return es, true
+}
+
+func (it *OptimalIter) push(i, j int) {
+	it.stack = append(it.stack, optimalFrame{i: i, j: j, choices: optimalSteps(it.cm, it.cost, it.ar, it.br, i, j)})
+}
+
+// advance moves to the next choice of the deepest frame which has one
+func (it *OptimalIter) advance() bool {
+	it.stack = it.stack[:len(it.stack)-1]
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if top.k++; top.k < len(top.choices) {
+			return true
+		}
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return false
+}
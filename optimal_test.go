@@ -0,0 +1,76 @@
+package lsdp
+
+import "testing"
+
+func TestCountOptimal(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		CM    CostModel
+		A     string
+		B     string
+		Dist  float64
+		Count int64
+	}{
+		{std, "", "", 0, 1},
+		{std, "a", "", 1, 1},
+		{std, "aa", "a", 1, 2},
+		{std, "ab", "ba", 2, 3},
+		{std, "book", "back", 2, 1},
+		{std, "abc", "xyz", 3, 1},
+		{Weights{Insert: 1, Delete: 1, Replace: 2}, "abc", "xyz", 6, 63},
+		{Weights{Insert: 0.1, Delete: 0.2, Replace: 0.3}, "a", "b", 0.3, 3},
+	}
+	for i, td := range testdata {
+		d, n := CountOptimal(td.CM, td.A, td.B)
+		if !equals(d, td.Dist) || n.Int64() != td.Count {
+			t.Errorf(`%d: CountOptimal("%s", "%s") = %f, %v, want %f, %d`, i, td.A, td.B, d, n, td.Dist, td.Count)
+		}
+	}
+}
+
+func TestOptimal(t *testing.T) {
+	std := Weights{1, 1, 1}
+	want := []EditScript{
+		{{REPLACE, 'a', 'b'}, {REPLACE, 'b', 'a'}},
+		{{INSERT, 0, 'b'}, {NONE, 'a', 'a'}, {DELETE, 'b', 0}},
+		{{DELETE, 'a', 0}, {NONE, 'b', 'b'}, {INSERT, 0, 'a'}},
+	}
+	it := Optimal(std, "ab", "ba", 0)
+	if it.Distance() != 2 {
+		t.Errorf("Distance() = %f, want 2", it.Distance())
+	}
+	var got []EditScript
+	for es, ok := it.Next(); ok; es, ok = it.Next() {
+		got = append(got, es)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Optimal() enumerates %v, want %v", got, want)
+	}
+	for i := range got {
+		if !equalsScript(got[i], want[i]) {
+			t.Errorf("%d: Optimal() enumerates %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	_, canonical := Align(std, "ab", "ba")
+	if !equalsScript(got[0], canonical) {
+		t.Errorf("first of Optimal() is %v, Align() is %v", got[0], canonical)
+	}
+
+	limited := Optimal(Weights{Insert: 1, Delete: 1, Replace: 2}, "abc", "xyz", 5)
+	var n int
+	for _, ok := limited.Next(); ok; _, ok = limited.Next() {
+		n++
+	}
+	if n != 5 {
+		t.Errorf("Optimal() with limit 5 enumerates %d scripts", n)
+	}
+
+	empty := Optimal(std, "", "", 0)
+	if es, ok := empty.Next(); !ok || len(es) != 0 {
+		t.Errorf("Optimal() of empty strings = %v, %v", es, ok)
+	}
+	if _, ok := empty.Next(); ok {
+		t.Errorf("Optimal() of empty strings enumerates more than 1 script")
+	}
+}
@@ -0,0 +1,161 @@
+package lsdp
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// QGramIndex is Searcher which filters candidates by q-gram counting and length before measuring by dm.
+// The candidates include every string within the unit edit distance maxEdits from the query.
+// A string within the weighted distance d needs d divided by the cheapest edit cost of dm edits at most,
+// so Search caps maxDist at maxEdits multiplied by the cheapest edit cost.
+type QGramIndex struct {
+	dm       DistanceMeasurer
+	minCost  float64
+	q        int
+	maxEdits int
+	strs     []string
+	byLen    map[int][]int
+	postings map[string][]qgramPosting
+}
+
+type qgramPosting struct {
+	idx   int
+	count int
+}
+
+// NewQGramIndex returns QGramIndex of strs, q less than 1 means 2.
+// dm must be Weights, *WeightsByRune or *FrozenWeightsByRune whose every edit costs more than 0,
+// other measurers like Normalized aren't counted in edits and are rejected.
+func NewQGramIndex(dm DistanceMeasurer, strs []string, q, maxEdits int) (*QGramIndex, error) {
+	mc, ok := dm.(minEditCoster)
+	if !ok {
+		return nil, errors.New("lsdp: QGramIndex needs Weights, WeightsByRune or FrozenWeightsByRune")
+	}
+	minCost := mc.minEditCost()
+	if !(minCost > 0) {
+		return nil, errors.New("lsdp: every edit cost of QGramIndex must be more than 0")
+	}
+	if q < 1 {
+		q = 2
+	}
+	qi := &QGramIndex{
+		dm:       dm,
+		minCost:  minCost,
+		q:        q,
+		maxEdits: maxEdits,
+		strs:     strs,
+		byLen:    make(map[int][]int),
+		postings: make(map[string][]qgramPosting),
+	}
+	for i, s := range strs {
+		rs := []rune(s)
+		qi.byLen[len(rs)] = append(qi.byLen[len(rs)], i)
+		for g, c := range qgrams(rs, q) {
+			qi.postings[g] = append(qi.postings[g], qgramPosting{idx: i, count: c})
+		}
+	}
+	return qi, nil
+}
+
+// minEditCoster provides the cheapest cost of a single edit
+type minEditCoster interface {
+	minEditCost() float64
+}
+
+func (w Weights) minEditCost() float64 {
+	return math.Min(w.Insert, math.Min(w.Delete, w.Replace))
+}
+
+// minEditCost returns the cheapest cost of the base weights and any rule, replacing a rune by itself isn't an edit
+func (wr *WeightsByRune) minEditCost() float64 {
+	min := wr.w.minEditCost()
+	for _, c := range wr.insRune {
+		min = math.Min(min, c)
+	}
+	for _, c := range wr.delRune {
+		min = math.Min(min, c)
+	}
+	for rs, c := range wr.repRune {
+		if rs[0] != rs[1] {
+			min = math.Min(min, c)
+		}
+	}
+	for _, c := range wr.insClass {
+		min = math.Min(min, c.cost)
+	}
+	for _, c := range wr.delClass {
+		min = math.Min(min, c.cost)
+	}
+	for _, c := range wr.repClass {
+		min = math.Min(min, c.cost)
+	}
+	return min
+}
+
+func (f *FrozenWeightsByRune) minEditCost() float64 {
+	return f.wr.minEditCost()
+}
+
+// qgrams returns the q-gram profile, a string shorter than q has no q-gram
+func qgrams(rs []rune, q int) map[string]int {
+	p := make(map[string]int)
+	for i := 0; i+q <= len(rs); i++ {
+		p[string(rs[i:i+q])]++
+	}
+	return p
+}
+
+// Candidates returns the indexes of strs which can be within maxEdits from orig, in ascending order.
+// By the count filter, such a string shares at least max(|orig|, |s|) - q + 1 - maxEdits*q q-grams with orig.
+func (qi *QGramIndex) Candidates(orig string) []int {
+	rs := []rune(orig)
+	shared := make(map[int]int)
+	for g, c := range qgrams(rs, qi.q) {
+		for _, p := range qi.postings[g] {
+			if p.count < c {
+				shared[p.idx] += p.count
+			} else {
+				shared[p.idx] += c
+			}
+		}
+	}
+
+	var cands []int
+	for l := len(rs) - qi.maxEdits; l <= len(rs)+qi.maxEdits; l++ {
+		max := len(rs)
+		if l > max {
+			max = l
+		}
+		threshold := max - qi.q + 1 - qi.maxEdits*qi.q
+		for _, idx := range qi.byLen[l] {
+			if threshold <= 0 || shared[idx] >= threshold {
+				cands = append(cands, idx)
+			}
+		}
+	}
+	sort.Ints(cands)
+	return cands
+}
+
+// Search returns at most k nearest strings within maxDist from orig, maxDist is capped by maxEdits of the index
+// multiplied by the cheapest edit cost since a farther string may not be among the candidates.
+func (qi *QGramIndex) Search(orig string, k int, maxDist float64) []Result {
+	if max := float64(qi.maxEdits) * qi.minCost; maxDist > max {
+		maxDist = max
+	}
+	rs := []Result{}
+	for _, idx := range qi.Candidates(orig) {
+		if d := qi.dm.Distance(orig, qi.strs[idx]); d <= maxDist {
+			rs = append(rs, Result{Str: qi.strs[idx], Dist: d})
+		}
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].Dist < rs[j].Dist
+	})
+	if k > 0 && k < len(rs) {
+		rs = rs[:k]
+	}
+	return rs
+}
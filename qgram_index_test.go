@@ -0,0 +1,126 @@
+package lsdp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestQGramIndex_Candidates(t *testing.T) {
+	std := Weights{1, 1, 1}
+	dict := append(makeDictionary(rand.New(rand.NewSource(1)), 300), "", "a", "getUser", "こんにちは", "こんばんは")
+	queries := []string{"", "a", "getUser", "getusr", "setItemz", "isVal", "newListCount", "xyz", "こんにちわ"}
+	for _, q := range []int{1, 2, 3} {
+		for maxEdits := 0; maxEdits <= 3; maxEdits++ {
+			qi, err := NewQGramIndex(std, dict, q, maxEdits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, query := range queries {
+				cands := make(map[int]bool)
+				for _, idx := range qi.Candidates(query) {
+					cands[idx] = true
+				}
+				for idx, s := range dict {
+					if Lsd(query, s) <= maxEdits && !cands[idx] {
+						t.Errorf(`q=%d k=%d: Candidates("%s") misses "%s"`, q, maxEdits, query, s)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestQGramIndex_Search(t *testing.T) {
+	dict := append(makeDictionary(rand.New(rand.NewSource(1)), 300), "", "getUser", "getUser", "こんにちは", "こんばんは")
+	queries := []string{"", "getUser", "getusr", "setItemz", "isVal", "newListCount", "xyz", "こんにちわ"}
+	half := Weights{0.5, 0.5, 0.5}
+	for _, dm := range []DistanceMeasurer{
+		Weights{1, 1, 1},
+		Weights{Insert: 1, Delete: 2, Replace: 1.5},
+		half,
+		ByRune(&half).Replace("gs", "GS", 0.25),
+		ByRune(&Weights{1, 1, 1}).Insert("e", 0.5).Freeze(),
+	} {
+		qi, err := NewQGramIndex(dm, dict, 2, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		linear := Linear(dm, dict)
+		max := 2 * dm.(minEditCoster).minEditCost()
+		for _, q := range queries {
+			for _, td := range []struct {
+				K       int
+				MaxDist float64
+			}{{1, max}, {5, max}, {0, max}, {3, max / 2}, {0, 0}} {
+				want := linear.Search(q, td.K, td.MaxDist)
+				if rs := qi.Search(q, td.K, td.MaxDist); !equalsResults(rs, want) {
+					t.Errorf(`QGramIndex(%v).Search("%s", %d, %f) = %v, want %v`, dm, q, td.K, td.MaxDist, rs, want)
+				}
+			}
+		}
+	}
+
+	std := Weights{1, 1, 1}
+	for _, d := range [][]string{dict, nil} {
+		qi, err := NewQGramIndex(std, d, 2, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSearcher(t, "QGramIndex", qi, std, d, 2)
+	}
+
+	qi, _ := NewQGramIndex(std, dict, 0, 1)
+	if rs := qi.Search("getUsr", 1, 1); len(rs) != 1 || rs[0].Str != "getUser" {
+		t.Errorf(`Search("getUsr") = %v, want getUser`, rs)
+	}
+
+	// maxDist over maxEdits is capped, a farther string must not be found instead of the true nearest
+	small := []string{"bbb", "cccbcc", "ccb", "abcbc", "bcacaa"}
+	qi, _ = NewQGramIndex(std, small, 2, 1)
+	for _, q := range []string{"bcccb", "ccb", "abc"} {
+		want := Linear(std, small).Search(q, 1, 1)
+		if rs := qi.Search(q, 1, math.Inf(1)); !equalsResults(rs, want) {
+			t.Errorf(`Search("%s", 1, +Inf) = %v, want %v`, q, rs, want)
+		}
+	}
+}
+
+func TestNewQGramIndex_Error(t *testing.T) {
+	std := Weights{1, 1, 1}
+	for _, dm := range []DistanceMeasurer{
+		Normalized(std),
+		Weights{1, 0, 1},
+		ByRune(&std).Delete("x", 0),
+		ByRune(&std).Replace("a", "b", -1).Freeze(),
+	} {
+		if _, err := NewQGramIndex(dm, []string{"abc"}, 2, 1); err == nil {
+			t.Errorf("NewQGramIndex(%v) returns no error", dm)
+		}
+	}
+	if _, err := NewQGramIndex(ByRune(&std).Replace("a", "a", 0), []string{"abc"}, 2, 1); err != nil {
+		t.Errorf("NewQGramIndex() with the rule replacing a rune by itself returns %v", err)
+	}
+}
+
+// maxDist is capped at maxEdits of the cheapest edits, not at maxEdits itself
+func TestQGramIndex_SearchCheapWeights(t *testing.T) {
+	w := Weights{0.5, 0.5, 0.5}
+	dict := []string{"getUser", "getUsers", "gotUsr", "setItem"}
+	qi, err := NewQGramIndex(w, dict, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Linear(w, dict).Search("getUsr", 0, 1)
+	if rs := qi.Search("getUsr", 0, math.Inf(1)); len(rs) != 3 || !equalsResults(rs, want) {
+		t.Errorf(`Search("getUsr", 0, +Inf) = %v, want %v`, rs, want)
+	}
+}
+
+func BenchmarkQGramIndex_Search(b *testing.B) {
+	qi, _ := NewQGramIndex(Weights{1, 1, 1}, makeDictionary(rand.New(rand.NewSource(1)), 1000), 2, 2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		qi.Search("getUserNam", 1, 2)
+	}
+}
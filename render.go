@@ -0,0 +1,193 @@
+package lsdp
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Inline returns the edit script rendered in a line, e.g. "[-k-]{+s+}itt[-e-]{+i+}n{+g+}"
+func (es EditScript) Inline() string {
+	return es.render("[-", "-]", "{+", "+}", func(s string) string { return s })
+}
+
+// ANSI returns the edit script rendered in a line with ANSI colors, deleted runes in red and inserted runes in green
+func (es EditScript) ANSI() string {
+	return es.render("\x1b[31m", "\x1b[0m", "\x1b[32m", "\x1b[0m", func(s string) string { return s })
+}
+
+// HTML returns the edit script rendered in HTML with del and ins elements
+func (es EditScript) HTML() string {
+	return es.render("<del>", "</del>", "<ins>", "</ins>", html.EscapeString)
+}
+
+// render writes each run of edits between matched runes as deleted runes followed by inserted runes
+func (es EditScript) render(delOpen, delClose, insOpen, insClose string, escape func(string) string) string {
+	var sb strings.Builder
+	var del, ins []rune
+	flush := func() {
+		if len(del) > 0 {
+			sb.WriteString(delOpen + escape(string(del)) + delClose)
+		}
+		if len(ins) > 0 {
+			sb.WriteString(insOpen + escape(string(ins)) + insClose)
+		}
+		del, ins = del[:0], ins[:0]
+	}
+	for _, e := range es {
+		if e.Type == NONE {
+			flush()
+			sb.WriteString(escape(string(e.Src)))
+			continue
+		}
+		if e.Type != INSERT {
+			del = append(del, e.Src)
+		}
+		if e.Type != DELETE {
+			ins = append(ins, e.Dest)
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// Aligned returns the edit script rendered in 3 lines: the source, indicators and the destination.
+// The indicator is '|' for matched, '*' for replaced and ' ' for inserted or deleted, and '-' fills the gaps.
+// A literal '-' or '\' of the strings is escaped by '\', so it can't be taken for a gap.
+//
+//	kitten-
+//	*|||*|
+//	sitting
+//
+// Each column is as wide as its wider rune on a terminal, East Asian wide and fullwidth runes and emoji take 2 columns.
+// The widths are approximated by the blocks of those runes, zero width and combining runes are regarded as 1 column.
+func (es EditScript) Aligned() string {
+	var src, ind, dest strings.Builder
+	for _, e := range es {
+		switch e.Type {
+		case INSERT:
+			writeColumn(&src, &ind, &dest, gap, ' ', e.Dest)
+		case DELETE:
+			writeColumn(&src, &ind, &dest, e.Src, ' ', gap)
+		case REPLACE:
+			writeColumn(&src, &ind, &dest, e.Src, '*', e.Dest)
+		default:
+			writeColumn(&src, &ind, &dest, e.Src, '|', e.Dest)
+		}
+	}
+	return src.String() + "\n" + ind.String() + "\n" + dest.String()
+}
+
+// writeColumn writes a column of Aligned, the indicator is repeated and narrower cells are padded to the column width.
+// A gap is filled with '-' across the column.
+func writeColumn(src, ind, dest *strings.Builder, s, i, d rune) {
+	ss, sw := alignedCell(s)
+	ds, dw := alignedCell(d)
+	w := sw
+	if dw > w {
+		w = dw
+	}
+	for _, c := range []struct {
+		sb   *strings.Builder
+		cell string
+		w    int
+	}{{src, ss, sw}, {dest, ds, dw}} {
+		if c.cell == "" {
+			c.sb.WriteString(strings.Repeat("-", w))
+			continue
+		}
+		c.sb.WriteString(c.cell)
+		c.sb.WriteString(strings.Repeat(" ", w-c.w))
+	}
+	ind.WriteString(strings.Repeat(string(i), w))
+}
+
+// gap marks the missing rune of an inserted or deleted column
+const gap rune = -1
+
+// alignedCell returns r escaped for Aligned and its width, the empty string for gap
+func alignedCell(r rune) (string, int) {
+	switch r {
+	case gap:
+		return "", 1
+	case '-', '\\':
+		return "\\" + string(r), 2
+	}
+	return string(r), runeWidth(r)
+}
+
+// runeWidth returns the number of terminal columns r takes, 2 for East Asian wide and fullwidth runes and emoji
+func runeWidth(r rune) int {
+	if unicode.Is(wideRunes, r) {
+		return 2
+	}
+	return 1
+}
+
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1}, // Hangul Jamo initial consonants
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1}, // watch, hourglass
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1}, // angle brackets
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1}, // media control emoji
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1}, // zodiac signs
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1}, // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1}, // Hiragana, Katakana, Bopomofo, compatibility Jamo, CJK compatibility
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1}, // CJK unified ideographs extension A
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1}, // CJK unified ideographs
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1}, // Yi
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1}, // Hangul Jamo extended A
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1}, // Hangul syllables
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1}, // CJK compatibility ideographs
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1}, // vertical forms
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1}, // CJK compatibility forms, small form variants
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1}, // fullwidth forms
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1}, // fullwidth signs
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1}, // ideographic symbols
+		{Lo: 0x17000, Hi: 0x18cff, Stride: 1}, // Tangut, Khitan
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1}, // Kana supplement and extensions, Nushu
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1}, // squared latin letters
+		{Lo: 0x1f200, Hi: 0x1f2ff, Stride: 1}, // enclosed ideographic supplement
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1}, // pictographs and emoticons
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1}, // transport and map symbols
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1}, // colored circles and squares
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1}, // supplemental symbols and pictographs
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1}, // symbols and pictographs extended A
+		{Lo: 0x20000, Hi: 0x3fffd, Stride: 1}, // CJK unified ideographs extension B and later
+	},
+}
@@ -0,0 +1,91 @@
+package lsdp
+
+import "testing"
+
+func TestEditScript_Render(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		A       string
+		B       string
+		Inline  string
+		Aligned string
+		ANSI    string
+		HTML    string
+	}{
+		{"", "", "", "\n\n", "", ""},
+		{"kitten", "sitting",
+			"[-k-]{+s+}itt[-e-]{+i+}n{+g+}",
+			"kitten-\n*|||*| \nsitting",
+			"\x1b[31mk\x1b[0m\x1b[32ms\x1b[0mitt\x1b[31me\x1b[0m\x1b[32mi\x1b[0mn\x1b[32mg\x1b[0m",
+			"<del>k</del><ins>s</ins>itt<del>e</del><ins>i</ins>n<ins>g</ins>"},
+		{"a<b>", "a&b",
+			"a[-<-]{+&+}b[->-]",
+			"a<b>\n|*| \na&b-",
+			"a\x1b[31m<\x1b[0m\x1b[32m&\x1b[0mb\x1b[31m>\x1b[0m",
+			"a<del>&lt;</del><ins>&amp;</ins>b<del>&gt;</del>"},
+		{"こんにちは", "こんばんは",
+			"こん[-にち-]{+ばん+}は",
+			"こんにちは\n||||****||\nこんばんは",
+			"こん\x1b[31mにち\x1b[0m\x1b[32mばん\x1b[0mは",
+			"こん<del>にち</del><ins>ばん</ins>は"},
+	}
+	for i, td := range testdata {
+		_, es := Align(std, td.A, td.B)
+		if s := es.Inline(); s != td.Inline {
+			t.Errorf(`%d: Inline() = %q, want %q`, i, s, td.Inline)
+		}
+		if s := es.Aligned(); s != td.Aligned {
+			t.Errorf(`%d: Aligned() = %q, want %q`, i, s, td.Aligned)
+		}
+		if s := es.ANSI(); s != td.ANSI {
+			t.Errorf(`%d: ANSI() = %q, want %q`, i, s, td.ANSI)
+		}
+		if s := es.HTML(); s != td.HTML {
+			t.Errorf(`%d: HTML() = %q, want %q`, i, s, td.HTML)
+		}
+	}
+}
+
+func TestEditScript_AlignedWide(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		A, B string
+		Want string
+	}{
+		{"", "あ", "--\n  \nあ"},
+		{"あ", "", "あ\n  \n--"},
+		{"aい", "aあ", "aい\n|**\naあ"},
+		{"a-", "aあ", "a\\-\n|**\naあ"},
+		{"a-", "a", "a\\-\n|  \na--"},
+		{"a", "a\\", "a--\n|  \na\\\\"},
+		{"🚀", "", "🚀\n  \n--"},
+		{"ｶﾅ", "カナ", "ｶ ﾅ \n****\nカナ"},
+	}
+	for i, td := range testdata {
+		_, es := Align(std, td.A, td.B)
+		if s := es.Aligned(); s != td.Want {
+			t.Errorf(`%d: Aligned() = %q, want %q`, i, s, td.Want)
+		}
+	}
+
+	for _, td := range []struct {
+		R    rune
+		Want int
+	}{{'a', 1}, {'-', 1}, {'あ', 2}, {'漢', 2}, {'한', 2}, {'Ａ', 2}, {'ｶ', 1}, {'é', 1}, {'🚀', 2}, {'😀', 2}, {'⌚', 2}, {'𠀋', 2}} {
+		if w := runeWidth(td.R); w != td.Want {
+			t.Errorf("runeWidth(%q) = %d, want %d", td.R, w, td.Want)
+		}
+	}
+
+	// unicode.Is needs sorted ranges which don't overlap
+	for i := 1; i < len(wideRunes.R16); i++ {
+		if wideRunes.R16[i-1].Hi >= wideRunes.R16[i].Lo {
+			t.Errorf("wideRunes.R16[%d] overlaps the previous range", i)
+		}
+	}
+	for i := 1; i < len(wideRunes.R32); i++ {
+		if wideRunes.R32[i-1].Hi >= wideRunes.R32[i].Lo {
+			t.Errorf("wideRunes.R32[%d] overlaps the previous range", i)
+		}
+	}
+}
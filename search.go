@@ -0,0 +1,171 @@
+package lsdp
+
+import (
+	"math"
+	"sort"
+)
+
+// Searcher provides nearest string search over a dictionary
+type Searcher interface {
+	// Search returns at most k nearest strings within maxDist from orig, all of them if k is 0 or less.
+	// Results are ordered by distance, ties are broken by the order of the dictionary.
+	// The results are never nil, an empty slice means nothing is found.
+	Search(orig string, k int, maxDist float64) []Result
+}
+
+// Linear returns Searcher measuring every string of strs by dm, the same as Nearest and NearestK.
+// Unlike NearestK, k of 0 or less means no limit as Searcher does.
+func Linear(dm DistanceMeasurer, strs []string) Searcher {
+	return linearSearcher{dm: dm, strs: strs}
+}
+
+type linearSearcher struct {
+	dm   DistanceMeasurer
+	strs []string
+}
+
+func (ls linearSearcher) Search(orig string, k int, maxDist float64) []Result {
+	n := len(ls.strs)
+	if k > 0 && k < n {
+		n = k
+	}
+	rs := []Result{}
+	for _, r := range NearestK(ls.dm, orig, ls.strs, n) {
+		if r.Dist > maxDist {
+			break
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// Trie is Searcher over a prefix tree of the dictionary, the DP rows are shared among strings with a common prefix.
+// Costs of the CostModel must not be negative.
+type Trie struct {
+	cm   CostModel
+	strs []string
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal []int
+}
+
+// NewTrie returns Trie of the dictionary strs measured by cm
+func NewTrie(cm CostModel, strs []string) *Trie {
+	t := &Trie{cm: cm, strs: strs, root: &trieNode{}}
+	for i, s := range strs {
+		n := t.root
+		for _, r := range s {
+			if n.children == nil {
+				n.children = make(map[rune]*trieNode)
+			}
+			child, ok := n.children[r]
+			if !ok {
+				child = &trieNode{}
+				n.children[r] = child
+			}
+			n = child
+		}
+		n.terminal = append(n.terminal, i)
+	}
+	return t
+}
+
+// Nearest returns the nearest string in the dictionary, the same as Nearest
+func (t *Trie) Nearest(orig string) (string, float64) {
+	rs := t.Search(orig, 1, math.Inf(1))
+	if len(rs) == 0 {
+		return "", 0
+	}
+	return rs[0].Str, rs[0].Dist
+}
+
+// Search returns at most k nearest strings within maxDist from orig, subtrees whose every row cost exceeds the bound are pruned
+func (t *Trie) Search(orig string, k int, maxDist float64) []Result {
+	s := &trieSearch{t: t, ar: []rune(orig), k: k, maxDist: maxDist}
+	row := make([]float64, len(s.ar)+1)
+	for i := 1; i < len(row); i++ {
+		row[i] = row[i-1] + t.cm.DeleteCost(s.ar[i-1])
+	}
+	s.visit(t.root, row, 1)
+
+	rs := make([]Result, len(s.found))
+	for i, f := range s.found {
+		rs[i] = Result{Str: t.strs[f.idx], Dist: f.dist}
+	}
+	return rs
+}
+
+type trieSearch struct {
+	t       *Trie
+	ar      []rune
+	k       int
+	maxDist float64
+	rows    [][]float64
+	found   []trieFound
+}
+
+type trieFound struct {
+	idx  int
+	dist float64
+}
+
+// bound returns the distance which a candidate must not exceed
+func (s *trieSearch) bound() float64 {
+	if s.k > 0 && len(s.found) == s.k && s.found[s.k-1].dist < s.maxDist {
+		return s.found[s.k-1].dist
+	}
+	return s.maxDist
+}
+
+func (s *trieSearch) add(f trieFound) {
+	i := sort.Search(len(s.found), func(i int) bool {
+		g := s.found[i]
+		return f.dist < g.dist || f.dist == g.dist && f.idx < g.idx
+	})
+	if s.k > 0 && i >= s.k {
+		return
+	}
+	s.found = append(s.found, trieFound{})
+	copy(s.found[i+1:], s.found[i:])
+	s.found[i] = f
+	if s.k > 0 && len(s.found) > s.k {
+		s.found = s.found[:s.k]
+	}
+}
+
+// visit collects the strings ending at n, and descends into the children with the rows of depth
+func (s *trieSearch) visit(n *trieNode, row []float64, depth int) {
+	if d := row[len(row)-1]; d <= s.bound() {
+		for _, idx := range n.terminal {
+			s.add(trieFound{idx: idx, dist: d})
+		}
+	}
+	if len(n.children) == 0 {
+		return
+	}
+
+	if len(s.rows) < depth {
+		s.rows = append(s.rows, make([]float64, len(row)))
+	}
+	next := s.rows[depth-1]
+	cm := s.t.cm
+	for br, child := range n.children {
+		next[0] = row[0] + cm.InsertCost(br)
+		min := next[0]
+		for i := 1; i < len(next); i++ {
+			next[i] = minCost(
+				row[i-1]+cm.ReplaceCost(s.ar[i-1], br),
+				row[i]+cm.InsertCost(br),
+				next[i-1]+cm.DeleteCost(s.ar[i-1]))
+			if next[i] < min {
+				min = next[i]
+			}
+		}
+		if min <= s.bound() {
+			s.visit(child, next, depth+1)
+		}
+	}
+}
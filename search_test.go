@@ -0,0 +1,150 @@
+package lsdp
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func equalsResults(a, b []Result) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Str != b[i].Str || !equals(a[i].Dist, b[i].Dist) {
+			return false
+		}
+	}
+	return true
+}
+
+// makeDictionary returns n identifiers drawn from rnd
+func makeDictionary(rnd *rand.Rand, n int) []string {
+	prefixes := []string{"get", "set", "is", "has", "new"}
+	words := []string{"User", "Name", "Id", "Count", "Value", "Item", "Items", "List"}
+	dict := make([]string, n)
+	for i := range dict {
+		dict[i] = prefixes[rnd.Intn(len(prefixes))] + words[rnd.Intn(len(words))]
+		if rnd.Intn(2) == 0 {
+			dict[i] += words[rnd.Intn(len(words))]
+		}
+	}
+	return dict
+}
+
+func TestNearestK(t *testing.T) {
+	std := Weights{1, 1, 1}
+	group := []string{"apple", "orange", "lemon", "water melon", "melon"}
+	testdata := []struct {
+		K    int
+		Want []Result
+	}{
+		{0, []Result{}},
+		{-1, []Result{}},
+		{2, []Result{{"lemon", 2}, {"melon", 2}}},
+		{3, []Result{{"lemon", 2}, {"melon", 2}, {"apple", 5}}},
+		{10, []Result{{"lemon", 2}, {"melon", 2}, {"apple", 5}, {"orange", 5}, {"water melon", 8}}},
+	}
+	for i, td := range testdata {
+		if rs := NearestK(std, "mon", group, td.K); !equalsResults(rs, td.Want) {
+			t.Errorf("%d: NearestK() = %v, want %v", i, rs, td.Want)
+		}
+	}
+}
+
+// checkSearcher checks the contract of Searcher: results within maxDist are ordered by distance and then by dictionary,
+// k of 0 or less means no limit and nothing found is an empty slice, not nil
+func checkSearcher(t *testing.T, name string, s Searcher, dm DistanceMeasurer, dict []string, maxDist float64) {
+	t.Helper()
+	queries := []string{"", "getUser", "getusr", "setItemz", "isVal", "newListCount", "xyz", "こんにちわ"}
+	for _, q := range queries {
+		var all []Result
+		for _, str := range dict {
+			if d := dm.Distance(q, str); d <= maxDist {
+				all = append(all, Result{Str: str, Dist: d})
+			}
+		}
+		sort.SliceStable(all, func(i, j int) bool {
+			return all[i].Dist < all[j].Dist
+		})
+
+		for _, k := range []int{-1, 0, 1, 3, len(dict) + 1} {
+			want := all
+			if k > 0 && k < len(want) {
+				want = want[:k]
+			}
+			rs := s.Search(q, k, maxDist)
+			if rs == nil {
+				t.Errorf(`%s.Search("%s", %d, %f) = nil, want an empty slice`, name, q, k, maxDist)
+			} else if !equalsResults(rs, want) {
+				t.Errorf(`%s.Search("%s", %d, %f) = %v, want %v`, name, q, k, maxDist, rs, want)
+			}
+		}
+		if rs := s.Search(q, 0, -1); rs == nil || len(rs) != 0 {
+			t.Errorf(`%s.Search("%s", 0, -1) = %#v, want an empty slice`, name, q, rs)
+		}
+	}
+}
+
+func TestSearcher_Contract(t *testing.T) {
+	std := Weights{1, 1, 1}
+	dict := append(makeDictionary(rand.New(rand.NewSource(1)), 100), "", "getUser", "getUser", "こんにちは")
+	checkSearcher(t, "Linear", Linear(std, dict), std, dict, 2)
+	checkSearcher(t, "Trie", NewTrie(std, dict), std, dict, 2)
+	checkSearcher(t, "Linear", Linear(std, nil), std, nil, 2)
+	checkSearcher(t, "Trie", NewTrie(std, nil), std, nil, 2)
+}
+
+func TestTrie_Search(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).Insert("s", 0.1).Replace("I", "i", 0.2)
+	dict := makeDictionary(rand.New(rand.NewSource(1)), 200)
+	queries := []string{"", "getUser", "getusr", "setItemz", "isVal", "newListCount", "hasIdd", "xyz"}
+
+	for _, cm := range []CostModel{std, Weights{Insert: 0.5, Delete: 2, Replace: 1}, wr} {
+		trie := NewTrie(cm, dict)
+		linear := Linear(cm, dict)
+		for _, q := range queries {
+			for _, td := range []struct {
+				K       int
+				MaxDist float64
+			}{{1, math.Inf(1)}, {5, math.Inf(1)}, {0, 2}, {3, 1}, {0, 0}} {
+				want := linear.Search(q, td.K, td.MaxDist)
+				if rs := trie.Search(q, td.K, td.MaxDist); !equalsResults(rs, want) {
+					t.Errorf(`Trie(%v).Search("%s", %d, %f) = %v, want %v`, cm, q, td.K, td.MaxDist, rs, want)
+				}
+			}
+
+			s, d := trie.Nearest(q)
+			if ws, wd := Nearest(cm, q, dict); s != ws || !equals(d, wd) {
+				t.Errorf(`Trie(%v).Nearest("%s") = %s %f, want %s %f`, cm, q, s, d, ws, wd)
+			}
+			if rs, want := trie.Search(q, 5, math.Inf(1)), NearestK(cm, q, dict, 5); !equalsResults(rs, want) {
+				t.Errorf(`Trie(%v).Search("%s", 5) = %v, NearestK() = %v`, cm, q, rs, want)
+			}
+		}
+	}
+
+	if s, d := NewTrie(std, nil).Nearest("abc"); s != "" || d != 0 {
+		t.Errorf(`empty Trie.Nearest() = "%s" %f`, s, d)
+	}
+}
+
+func BenchmarkTrie_Search(b *testing.B) {
+	std := Weights{1, 1, 1}
+	trie := NewTrie(std, makeDictionary(rand.New(rand.NewSource(1)), 1000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Search("getUserNam", 1, math.Inf(1))
+	}
+}
+
+func BenchmarkLinear_Search(b *testing.B) {
+	std := Weights{1, 1, 1}
+	linear := Linear(std, makeDictionary(rand.New(rand.NewSource(1)), 1000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linear.Search("getUserNam", 1, math.Inf(1))
+	}
+}
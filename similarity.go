@@ -0,0 +1,105 @@
+package lsdp
+
+import "math"
+
+// Similarity provides measurement of the similarity between 2 strings, in [0, 1] and 1 means identical
+type Similarity interface {
+	Similarity(string, string) float64
+}
+
+// SimilarityFunc type is an adapter to allow the use of ordinary functions as Similarity.
+type SimilarityFunc func(string, string) float64
+
+// Similarity calls f(a,b)
+func (f SimilarityFunc) Similarity(a, b string) float64 {
+	return f(a, b)
+}
+
+// DistanceBounder provides the maximum possible distance between 2 strings
+type DistanceBounder interface {
+	MaxDistance(string, string) float64
+}
+
+// ToSimilarity returns what converted the DistanceMeasurer into Similarity.
+// If dm is DistanceBounder, the similarity is 1 - d/MaxDistance(a, b), otherwise 1/(1+d).
+func ToSimilarity(dm DistanceMeasurer) Similarity {
+	return similarity{wrapped: dm}
+}
+
+type similarity struct {
+	wrapped DistanceMeasurer
+}
+
+func (s similarity) Similarity(a, b string) float64 {
+	d := s.wrapped.Distance(a, b)
+	if d <= 0 {
+		return 1
+	}
+	db, ok := s.wrapped.(DistanceBounder)
+	if !ok {
+		return 1 / (1 + d)
+	}
+	max := db.MaxDistance(a, b)
+	if d >= max {
+		return 0
+	}
+	return 1 - d/max
+}
+
+// MaxDistance returns the maximum possible weighted Levenshtein distance between a and b
+func (w Weights) MaxDistance(a, b string) float64 {
+	return maxCost(w, w.Replace, a, b)
+}
+
+// MaxDistance returns the maximum possible weighted levenshtein distance by rune between a and b
+func (wr *WeightsByRune) MaxDistance(a, b string) float64 {
+	return maxCost(wr, wr.w.Replace, a, b)
+}
+
+// MaxDistance returns the maximum possible Levenshtein distance between a and b
+func (p LevenshteinParam) MaxDistance(a, b string) float64 {
+	l := len([]rune(a))
+	if lb := len([]rune(b)); l < lb {
+		l = lb
+	}
+	max := p.Insert
+	if p.Delete > max {
+		max = p.Delete
+	}
+	if p.Replace > max {
+		max = p.Replace
+	}
+	return float64(l) * max
+}
+
+// maxCost returns the cost of the cheaper of 2 trivial edit paths assuming no rune matches:
+// deleting all of a and inserting all of b, or replacing along the diagonal and inserting or deleting the rest.
+// An equal rune pair on the diagonal costs what cm charges for it, replacing or deleting and inserting,
+// but no less than mismatch, so the bound doesn't depend on whether runes happen to match.
+func maxCost(cm CostModel, mismatch float64, a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	var indel, diagonal float64
+	for i, r := range ar {
+		c := cm.DeleteCost(r)
+		indel += c
+		if i < len(br) && r == br[i] {
+			cell := math.Min(cm.ReplaceCost(r, r), c+cm.InsertCost(r))
+			diagonal += math.Max(cell, mismatch)
+		} else if i < len(br) {
+			diagonal += cm.ReplaceCost(r, br[i])
+		} else {
+			diagonal += c
+		}
+	}
+	for i, r := range br {
+		c := cm.InsertCost(r)
+		indel += c
+		if i >= len(ar) {
+			diagonal += c
+		}
+	}
+	if diagonal < indel {
+		return diagonal
+	}
+	return indel
+}
@@ -0,0 +1,67 @@
+package lsdp
+
+import "testing"
+
+func TestToSimilarity(t *testing.T) {
+	std := Weights{1, 1, 1}
+	heavy := Weights{Insert: 10, Delete: 10, Replace: 3}
+	var constd DistanceFunc = func(_, _ string) float64 {
+		return 1
+	}
+	testdata := []struct {
+		DM  DistanceMeasurer
+		A   string
+		B   string
+		Sim float64
+	}{
+		{std, "", "", 1},
+		{std, "abc", "abc", 1},
+		{std, "ab", "abcd", 0.5},
+		{std, "abc", "xyz", 0},
+		{std, "", "abc", 0},
+		{std, "book", "back", 0.5},
+		{heavy, "book", "back", 0.5},
+		{heavy, "ab", "abcd", 1 - 20.0/26},
+		{heavy, "abcd", "xbcd", 0.75},
+		{ByRune(&std).Replace("o", "a", 0.5), "book", "back", 1 - 1.5/3.5},
+		{LevenshteinParam{Insert: 1, Delete: 1, Replace: 2}, "book", "back", 0.5},
+		{constd, "a", "b", 0.5},
+	}
+	for i, td := range testdata {
+		if s := ToSimilarity(td.DM).Similarity(td.A, td.B); !equals(s, td.Sim) {
+			t.Errorf(`%d: Similarity("%s", "%s") = %f, want %f`, i, td.A, td.B, s, td.Sim)
+		}
+	}
+}
+
+func TestMaxDistance_EqualRuneCost(t *testing.T) {
+	std := Weights{1, 1, 1}
+	testdata := []struct {
+		CM   *WeightsByRune
+		A    string
+		B    string
+		Want float64
+	}{
+		{ByRune(&std).Replace("a", "a", 5), "a", "a", 2},
+		{ByRune(&std).Replace("a", "a", 1.5), "a", "a", 1.5},
+		{ByRune(&std).Replace("a", "a", 1.5), "aab", "aac", 4},
+		{ByRune(&std).Replace("b", "b", 0.5), "abc", "abc", 3},
+	}
+	for i, td := range testdata {
+		if d := td.CM.MaxDistance(td.A, td.B); !equals(d, td.Want) {
+			t.Errorf(`%d: MaxDistance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, td.Want)
+		}
+		if d, max := td.CM.Distance(td.A, td.B), td.CM.MaxDistance(td.A, td.B); d > max {
+			t.Errorf(`%d: Distance("%s", "%s") = %f exceeds MaxDistance() = %f`, i, td.A, td.B, d, max)
+		}
+	}
+}
+
+func TestSimilarityFunc(t *testing.T) {
+	var consts SimilarityFunc = func(_, _ string) float64 {
+		return 0.5
+	}
+	if s := consts.Similarity("a", "b"); s != 0.5 {
+		t.Errorf("Similarity() = %f, want 0.5", s)
+	}
+}
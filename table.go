@@ -0,0 +1,131 @@
+package lsdp
+
+// denseTableThreshold is the minimum product of byte lengths compiling a dense table pays for
+const denseTableThreshold = 256
+
+// asciiTableThreshold is the minimum product of lengths of ASCII strings compiling the whole ASCII table pays for
+const asciiTableThreshold = 128 * 128
+
+// runeTable holds the costs of CostModel densely for an alphabet, rep[si*k+di] is the cost of replacing
+type runeTable struct {
+	k   int
+	ins []float64
+	del []float64
+	rep []float64
+}
+
+func compileTable(cm CostModel, alphabet []rune) *runeTable {
+	k := len(alphabet)
+	t := &runeTable{
+		k:   k,
+		ins: make([]float64, k),
+		del: make([]float64, k),
+		rep: make([]float64, k*k),
+	}
+	for si, src := range alphabet {
+		t.ins[si] = cm.InsertCost(src)
+		t.del[si] = cm.DeleteCost(src)
+		for di, dest := range alphabet {
+			t.rep[si*k+di] = cm.ReplaceCost(src, dest)
+		}
+	}
+	return t
+}
+
+// compileASCIITable returns the table whose index is the byte value of ASCII
+func compileASCIITable(cm CostModel) *runeTable {
+	alphabet := make([]rune, 128)
+	for i := range alphabet {
+		alphabet[i] = rune(i)
+	}
+	return compileTable(cm, alphabet)
+}
+
+// distance returns the distance between index sequences of the alphabet
+func (t *runeTable) distance(ai, bi []int) float64 {
+	row := make([]float64, len(ai)+1)
+	for i := 1; i < len(row); i++ {
+		row[i] = row[i-1] + t.del[ai[i-1]]
+	}
+	for j := 1; j < len(bi)+1; j++ {
+		diagonal := row[0]
+		ins := t.ins[bi[j-1]]
+		rep := t.rep[bi[j-1]:]
+		row[0] += ins
+		for i := 1; i < len(row); i++ {
+			r := diagonal + rep[ai[i-1]*t.k]
+			diagonal = row[i]
+			row[i] = minCost(r, row[i]+ins, row[i-1]+t.del[ai[i-1]])
+		}
+	}
+	return row[len(row)-1]
+}
+
+// distanceASCII returns the distance between ASCII strings by the ASCII table
+func (t *runeTable) distanceASCII(a, b string) float64 {
+	row := make([]float64, len(a)+1)
+	for i := 1; i < len(row); i++ {
+		row[i] = row[i-1] + t.del[a[i-1]]
+	}
+	for j := 1; j < len(b)+1; j++ {
+		diagonal := row[0]
+		ins := t.ins[b[j-1]]
+		rep := t.rep[b[j-1]:]
+		row[0] += ins
+		for i := 1; i < len(row); i++ {
+			r := diagonal + rep[int(a[i-1])*t.k]
+			diagonal = row[i]
+			row[i] = minCost(r, row[i]+ins, row[i-1]+t.del[a[i-1]])
+		}
+	}
+	return row[len(row)-1]
+}
+
+// tableDistance returns the distance by the dense table of the alphabet appearing in a and b,
+// or by looking up cm directly if the alphabet is too large for the strings
+func tableDistance(cm CostModel, a, b string) float64 {
+	if isASCII(a) && isASCII(b) {
+		if len(a)*len(b) >= asciiTableThreshold {
+			return compileASCIITable(cm).distanceASCII(a, b)
+		}
+		var index [128]int // the index in alphabet + 1, 0 means absent
+		var alphabet []rune
+		indexes := func(s string) []int {
+			is := make([]int, len(s))
+			for i := 0; i < len(s); i++ {
+				if index[s[i]] == 0 {
+					alphabet = append(alphabet, rune(s[i]))
+					index[s[i]] = len(alphabet)
+				}
+				is[i] = index[s[i]] - 1
+			}
+			return is
+		}
+		ai, bi := indexes(a), indexes(b)
+		if len(alphabet)*len(alphabet) <= len(a)*len(b) {
+			return compileTable(cm, alphabet).distance(ai, bi)
+		}
+	}
+
+	ar, br := []rune(a), []rune(b)
+	index := make(map[rune]int)
+	var alphabet []rune
+	indexes := func(rs []rune) []int {
+		is := make([]int, len(rs))
+		for i, r := range rs {
+			idx, ok := index[r]
+			if !ok {
+				idx = len(alphabet)
+				index[r] = idx
+				alphabet = append(alphabet, r)
+			}
+			is[i] = idx
+		}
+		return is
+	}
+	ai, bi := indexes(ar), indexes(br)
+	if len(alphabet)*len(alphabet) > len(ar)*len(br) {
+		return rowDistance(cm, make([]float64, len(ar)+1), ar, br)
+	}
+	return compileTable(cm, alphabet).distance(ai, bi)
+}
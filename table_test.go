@@ -0,0 +1,97 @@
+package lsdp
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestTableDistance(t *testing.T) {
+	std := Weights{1, 1, 1}
+	wr := ByRune(&std).
+		Insert("a", 0.1).
+		Delete("b", 0.2).
+		Replace("c", "d", 0.3).
+		Replace("こ", "こ", 0.4).
+		InsertClass(unicode.IsSpace, 0.5)
+	testdata := []struct {
+		A string
+		B string
+	}{
+		{"", ""},
+		{"abc", ""},
+		{"", "abc"},
+		{"kitten", "sitting"},
+		{"こんにちは", "こんばんは"},
+		{strings.Repeat("abcd ", 10), strings.Repeat("dcba", 10)},
+		{strings.Repeat("こんにちは", 10), strings.Repeat("こんばんは", 10)},
+		{"abcdefghijklmnop", "qrstuvwxyzABCDEF"},
+		{strings.Repeat("kitten sitting ", 10), strings.Repeat("sitting, kitten", 10)},
+		{strings.Repeat("a b\x7f", 60), strings.Repeat("cd\x00", 60)},
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		testdata = append(testdata, struct {
+			A string
+			B string
+		}{randomString(rnd, rnd.Intn(40)), randomString(rnd, rnd.Intn(40))})
+	}
+
+	f := wr.Freeze()
+	for i, td := range testdata {
+		want := accumulateCost(td.A, td.B, wr.cellCost, minCost)
+		if d := tableDistance(wr, td.A, td.B); !equals(d, want) {
+			t.Errorf(`%d: tableDistance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, want)
+		}
+		if d := wr.Distance(td.A, td.B); !equals(d, want) {
+			t.Errorf(`%d: wr.Distance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, want)
+		}
+		if d := f.Distance(td.A, td.B); !equals(d, want) {
+			t.Errorf(`%d: frozen.Distance("%s", "%s") = %f, want %f`, i, td.A, td.B, d, want)
+		}
+	}
+}
+
+func benchWeightsByRune() *WeightsByRune {
+	return ByRune(&Weights{1, 1, 1}).
+		Insert("abc", 0.1).
+		Delete("xyz", 0.2).
+		Replace("0123456789", "0123456789", 0.3)
+}
+
+func BenchmarkWeightsByRuneDistance(b *testing.B) {
+	wr := benchWeightsByRune()
+	s := "abababababababababababababababababababababab"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wr.Distance(s, benchLongInput)
+	}
+}
+
+func BenchmarkWeightsByRuneDistanceLookup(b *testing.B) {
+	wr := benchWeightsByRune()
+	s := "abababababababababababababababababababababab"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		accumulateCost(s, benchLongInput, wr.cellCost, minCost)
+	}
+}
+
+func BenchmarkFrozenWeightsByRuneDistance(b *testing.B) {
+	f := benchWeightsByRune().Freeze()
+	s := "abababababababababababababababababababababab"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Distance(s, benchLongInput)
+	}
+}
+
+func BenchmarkWeightsByRuneDistanceASCII(b *testing.B) {
+	wr := benchWeightsByRune()
+	x, y := strings.Repeat("kitten sitting ", 4), strings.Repeat("sitting, kitten", 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wr.Distance(x, y)
+	}
+}
@@ -28,6 +28,29 @@ func Evaluate(dm DistanceMeasurer, findStrs []string, collectCases map[string]st
 	return
 }
 
+// Evaluate string classification by specified distance function normalized with each strategy.
+// names select strategies by Normalization name (e.g. "sum-length"), all strategies if empty.
+// Rates are keyed by strategy name.
+func EvaluateNormalized(dm DistanceMeasurer, findStrs []string, collectCases map[string]string, names ...string) (map[string]float64, error) {
+	norms := Normalizations
+	if len(names) > 0 {
+		norms = nil
+		for _, name := range names {
+			n, err := ParseNormalization(name)
+			if err != nil {
+				return nil, err
+			}
+			norms = append(norms, n)
+		}
+	}
+
+	rates := make(map[string]float64)
+	for _, n := range norms {
+		rates[n.String()], _ = Evaluate(Normalized(dm, n), findStrs, collectCases)
+	}
+	return rates, nil
+}
+
 /*
  Evaluate string classification by specified distance function & string csv files
 
@@ -90,3 +113,16 @@ func csv2Records(filename string) (records [][]string, err error) {
 	}
 	return records, nil
 }
+
+// Check metric axioms of specified distance function over strings in the first column of csv file
+func CheckMetricByCSV(dm DistanceMeasurer, corpusCsvFilename string, opts CheckOptions) ([]Violation, error) {
+	records, err := csv2Records(corpusCsvFilename)
+	if err != nil {
+		return nil, err
+	}
+	var corpus []string
+	for _, rec := range records {
+		corpus = append(corpus, rec[0])
+	}
+	return CheckMetric(dm, corpus, opts), nil
+}
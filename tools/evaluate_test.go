@@ -64,3 +64,66 @@ func TestLevesteinParam_EvaluateByCSV(t *testing.T) {
 		t.Errorf("fail str == %s, want 'backs'", reports[0].Raw)
 	}
 }
+
+func TestEvaluateNormalized(t *testing.T) {
+	param := Weights{Insert: 1, Delete: 1, Replace: 1}
+
+	findStrs := []string{
+		"book",
+		"back",
+		"cook",
+	}
+	evalCases := map[string]string{
+		"book":  "book",
+		"back":  "back",
+		"cook":  "cook",
+		"backs": "back",
+	}
+
+	rates, err := EvaluateNormalized(param, findStrs, evalCases)
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	if len(rates) != len(Normalizations) {
+		t.Errorf("rates is %d items, want %d items\nrates = %v", len(rates), len(Normalizations), rates)
+	}
+	for name, rate := range rates {
+		if rate != 1.0 {
+			t.Errorf("%s: rate == %f, want 1.0", name, rate)
+		}
+	}
+
+	rates, err = EvaluateNormalized(param, findStrs, evalCases, "sum-length")
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	if _, ok := rates["sum-length"]; !ok || len(rates) != 1 {
+		t.Errorf("rates = %v, want only sum-length", rates)
+	}
+
+	if _, err := EvaluateNormalized(param, findStrs, evalCases, "unknown"); err == nil {
+		t.Errorf("EvaluateNormalized() with unknown name returns no error")
+	}
+}
+
+func TestCheckMetricByCSV(t *testing.T) {
+	vs, err := CheckMetricByCSV(Weights{Insert: 1, Delete: 1, Replace: 1}, "testdata/pattern.csv", CheckOptions{})
+	if err != nil {
+		t.Errorf("err %v", err)
+	}
+	if len(vs) != 0 {
+		t.Errorf("violations = %v, want none", vs)
+	}
+
+	vs, err = CheckMetricByCSV(Weights{Insert: 1, Delete: 2, Replace: 1}, "testdata/pattern.csv", CheckOptions{MaxViolations: 1})
+	if err != nil {
+		t.Errorf("err %v", err)
+	}
+	if len(vs) != 1 || vs[0].Property != Symmetry {
+		t.Errorf("violations = %v, want 1 symmetry", vs)
+	}
+
+	if _, err := CheckMetricByCSV(Weights{Insert: 1, Delete: 1, Replace: 1}, "testdata/notfound.csv", CheckOptions{}); err == nil {
+		t.Errorf("CheckMetricByCSV() of missing file returns no error")
+	}
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+
+	. "github.com/deltam/go-lsd-parametrized"
+)
+
+// Report of edits aggregated over pairs of raw and collect strings
+type EditReport struct {
+	Pairs   int
+	Counts  EditCounts
+	Insert  map[rune]int
+	Delete  map[rune]int
+	Replace map[[2]rune]int
+}
+
+// Aggregate edits changing each raw string to its collect string along the optimal path of cm.
+// Insert counts the runes missing in raw strings, Delete counts the extra runes, and Replace is the confusion matrix.
+func CollectEditStats(cm CostModel, collectCases map[string]string) *EditReport {
+	r := &EditReport{
+		Insert:  make(map[rune]int),
+		Delete:  make(map[rune]int),
+		Replace: make(map[[2]rune]int),
+	}
+	for raw, collect := range collectCases {
+		_, es := Align(cm, raw, collect)
+		r.Pairs++
+		cnt := es.Counts()
+		for i := range r.Counts {
+			r.Counts[i] += cnt[i]
+		}
+		rc := es.RuneCounts()
+		for k, n := range rc.Insert {
+			r.Insert[k] += n
+		}
+		for k, n := range rc.Delete {
+			r.Delete[k] += n
+		}
+		for k, n := range rc.Replace {
+			r.Replace[k] += n
+		}
+	}
+	return r
+}
+
+// Aggregate edits by string csv file, the format is same as EvaluateByCSV
+func CollectEditStatsByCSV(cm CostModel, patternCsvFilename string) (*EditReport, error) {
+	records, err := csv2Records(patternCsvFilename)
+	if err != nil {
+		return nil, err
+	}
+	patternDict := make(map[string]string)
+	for _, rec := range records {
+		patternDict[rec[0]] = rec[1]
+	}
+	return CollectEditStats(cm, patternDict), nil
+}
+
+type editRecord struct {
+	Type  string
+	Src   string
+	Dest  string
+	Count int
+}
+
+// records returns every aggregated edit ordered by count descending
+func (r *EditReport) records() []editRecord {
+	var recs []editRecord
+	for k, n := range r.Insert {
+		recs = append(recs, editRecord{"insert", "", string(k), n})
+	}
+	for k, n := range r.Delete {
+		recs = append(recs, editRecord{"delete", string(k), "", n})
+	}
+	for k, n := range r.Replace {
+		recs = append(recs, editRecord{"replace", string(k[0]), string(k[1]), n})
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		a, b := recs[i], recs[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		} else if a.Type != b.Type {
+			return a.Type < b.Type
+		} else if a.Src != b.Src {
+			return a.Src < b.Src
+		}
+		return a.Dest < b.Dest
+	})
+	return recs
+}
+
+/*
+Write the report as csv ordered by count descending
+
+	type,src,dest,count
+	replace,o,a,3
+	insert,,s,2
+	...
+*/
+func (r *EditReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"type", "src", "dest", "count"}); err != nil {
+		return err
+	}
+	for _, rec := range r.records() {
+		if err := cw.Write([]string{rec.Type, rec.Src, rec.Dest, strconv.Itoa(rec.Count)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Marshal the report to JSON, runes are written as strings and Replace as nested objects of src and dest
+func (r *EditReport) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Pairs   int                       `json:"pairs"`
+		Counts  map[string]int            `json:"counts"`
+		Insert  map[string]int            `json:"insert"`
+		Delete  map[string]int            `json:"delete"`
+		Replace map[string]map[string]int `json:"replace"`
+	}{
+		Pairs: r.Pairs,
+		Counts: map[string]int{
+			"insert":  r.Counts.Get(INSERT),
+			"delete":  r.Counts.Get(DELETE),
+			"replace": r.Counts.Get(REPLACE),
+			"none":    r.Counts.Get(NONE),
+		},
+		Insert:  make(map[string]int),
+		Delete:  make(map[string]int),
+		Replace: make(map[string]map[string]int),
+	}
+	for k, n := range r.Insert {
+		v.Insert[string(k)] = n
+	}
+	for k, n := range r.Delete {
+		v.Delete[string(k)] = n
+	}
+	for k, n := range r.Replace {
+		src := string(k[0])
+		if v.Replace[src] == nil {
+			v.Replace[src] = make(map[string]int)
+		}
+		v.Replace[src][string(k[1])] = n
+	}
+	return json.Marshal(v)
+}
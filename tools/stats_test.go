@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/deltam/go-lsd-parametrized"
+)
+
+func TestCollectEditStats(t *testing.T) {
+	std := Weights{Insert: 1, Delete: 1, Replace: 1}
+	cases := map[string]string{
+		"bok":   "book",
+		"baack": "back",
+		"cool":  "cook",
+		"bool":  "book",
+	}
+
+	r := CollectEditStats(std, cases)
+	if r.Pairs != 4 {
+		t.Errorf("Pairs == %d, want 4", r.Pairs)
+	}
+	if r.Counts != (EditCounts{1, 1, 2, 13}) {
+		t.Errorf("Counts == %v, want [1 1 2 13]", r.Counts)
+	}
+	if len(r.Insert) != 1 || r.Insert['o'] != 1 {
+		t.Errorf("Insert == %v, want map[o:1]", r.Insert)
+	}
+	if len(r.Delete) != 1 || r.Delete['a'] != 1 {
+		t.Errorf("Delete == %v, want map[a:1]", r.Delete)
+	}
+	if len(r.Replace) != 1 || r.Replace[[2]rune{'l', 'k'}] != 2 {
+		t.Errorf("Replace == %v, want l->k 2 times", r.Replace)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("err %v", err)
+	}
+	want := "type,src,dest,count\nreplace,l,k,2\ndelete,a,,1\ninsert,,o,1\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() writes %q, want %q", buf.String(), want)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	wantJSON := `{"pairs":4,"counts":{"delete":1,"insert":1,"none":13,"replace":2},"insert":{"o":1},"delete":{"a":1},"replace":{"l":{"k":2}}}`
+	if string(b) != wantJSON {
+		t.Errorf("json = %s, want %s", b, wantJSON)
+	}
+}
+
+func TestCollectEditStatsByCSV(t *testing.T) {
+	r, err := CollectEditStatsByCSV(Weights{Insert: 1, Delete: 1, Replace: 1}, "testdata/pattern.csv")
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	if r.Pairs != 4 || r.Counts.Get(DELETE) != 1 || r.Counts.Get(REPLACE) != 3 {
+		t.Errorf("report = %+v", r)
+	}
+
+	if _, err := CollectEditStatsByCSV(Weights{Insert: 1, Delete: 1, Replace: 1}, "testdata/notfound.csv"); err == nil {
+		t.Errorf("CollectEditStatsByCSV() of missing file returns no error")
+	}
+}